@@ -0,0 +1,40 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestInputPaths(t *testing.T) {
+	// フォルダは中の .txt .tsv ファイルにし、指定した順番によらずファイル名順で重複を除く
+
+	dir := t.TempDir()
+	in := filepath.Join(dir, "in")
+	for _, name := range []string{"0.txt", "in/b.txt", "in/a.TSV", "in/c.csv", "in/sub.txt/d.txt", "csv/x.csv"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := inputPaths([]string{filepath.Join(in, "b.txt"), in, filepath.Join(dir, "0.txt")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(dir, "0.txt"), filepath.Join(in, "a.TSV"), filepath.Join(in, "b.txt")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("inputPaths = %v, want %v", got, want)
+	}
+
+	if _, err := inputPaths([]string{filepath.Join(dir, "csv")}); err == nil {
+		t.Error("抽出ファイルが無くてもエラーになりません")
+	}
+	if _, err := inputPaths([]string{filepath.Join(dir, "none.txt")}); err == nil {
+		t.Error("無いファイルでもエラーになりません")
+	}
+}
@@ -2,4 +2,9 @@ module github.com/sei1rou/NwToRicohSanai
 
 go 1.17
 
-require golang.org/x/text v0.9.0 // indirect
+require (
+	golang.org/x/term v0.10.0
+	golang.org/x/text v0.9.0
+)
+
+require golang.org/x/sys v0.10.0 // indirect
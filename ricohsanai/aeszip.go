@@ -0,0 +1,245 @@
+package ricohsanai
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// WinZip AE-2 形式のAES-256暗号化ZIP
+// 7-Zip・WinZip・Lhaplusなど一般的な解凍ソフトでパスワードを入れて開ける
+const (
+	aesMethod     = 99 // 圧縮方式(AES暗号化)
+	aesExtraID    = 0x9901
+	aesStrength   = 3 // AES-256
+	aesKeyLen     = 32
+	aesSaltLen    = 16
+	aesIterations = 1000 // PBKDF2の繰り返し回数(WinZipの仕様)
+	aesAuthLen    = 10   // 認証コードの長さ
+)
+
+func WriteEncryptedZip(w io.Writer, name string, data []byte, modTime time.Time, password string) error {
+	// data を name というファイル名でAES-256暗号化したZIPに書き出す
+
+	if password == "" {
+		return errors.New("暗号化のパスワードがありません")
+	}
+
+	// 圧縮する
+	var deflated bytes.Buffer
+	fw, err := flate.NewWriter(&deflated, flate.DefaultCompression)
+	if err != nil {
+		return err
+	}
+	if _, err := fw.Write(data); err != nil {
+		return err
+	}
+	if err := fw.Close(); err != nil {
+		return err
+	}
+
+	// 鍵を作る（暗号化の鍵・認証の鍵・パスワード確認値）
+	salt := make([]byte, aesSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	keys := pbkdf2SHA1([]byte(password), salt, aesIterations, aesKeyLen*2+2)
+	encKey, authKey, verifier := keys[:aesKeyLen], keys[aesKeyLen:aesKeyLen*2], keys[aesKeyLen*2:]
+
+	// 暗号化して認証コードを付ける
+	block, err := aes.NewCipher(encKey)
+	if err != nil {
+		return err
+	}
+	enc := make([]byte, deflated.Len())
+	newAECTR(block).XORKeyStream(enc, deflated.Bytes())
+	mac := hmac.New(sha1.New, authKey)
+	mac.Write(enc)
+
+	payload := make([]byte, 0, len(salt)+len(verifier)+len(enc)+aesAuthLen)
+	payload = append(payload, salt...)
+	payload = append(payload, verifier...)
+	payload = append(payload, enc...)
+	payload = append(payload, mac.Sum(nil)[:aesAuthLen]...)
+
+	// AE-2 ではCRCを0にして、本当の圧縮方式は拡張フィールドに書く
+	extra := make([]byte, 11)
+	binary.LittleEndian.PutUint16(extra[0:], aesExtraID)
+	binary.LittleEndian.PutUint16(extra[2:], 7)
+	binary.LittleEndian.PutUint16(extra[4:], 2) // AE-2
+	copy(extra[6:], "AE")
+	extra[8] = aesStrength
+	binary.LittleEndian.PutUint16(extra[9:], zip.Deflate)
+
+	fh := &zip.FileHeader{
+		Name:               name,
+		Method:             aesMethod,
+		Flags:              0x1 | 0x800, // 暗号化・ファイル名がUTF-8
+		Extra:              extra,
+		CompressedSize64:   uint64(len(payload)),
+		UncompressedSize64: uint64(len(data)),
+	}
+	fh.ModifiedDate, fh.ModifiedTime = dosTime(modTime)
+
+	zw := zip.NewWriter(w)
+	f, err := zw.CreateRaw(fh)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(payload); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+func ReadEncryptedZip(r io.ReaderAt, size int64, password string) ([]byte, error) {
+	// WriteEncryptedZip で書き出したZIPを復号して、中のファイルの内容を返す
+
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	if len(zr.File) != 1 {
+		return nil, fmt.Errorf("暗号化ZIPのファイル数が違います(%d)", len(zr.File))
+	}
+	f := zr.File[0]
+
+	extra := f.Extra
+	if f.Method != aesMethod || len(extra) < 11 || binary.LittleEndian.Uint16(extra[0:]) != aesExtraID || extra[8] != aesStrength {
+		return nil, errors.New("AES-256で暗号化されたZIPではありません")
+	}
+	method := binary.LittleEndian.Uint16(extra[9:])
+
+	raw, err := f.OpenRaw()
+	if err != nil {
+		return nil, err
+	}
+	payload, err := io.ReadAll(raw)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) < aesSaltLen+2+aesAuthLen {
+		return nil, errors.New("暗号化ZIPのデータが短すぎます")
+	}
+	salt := payload[:aesSaltLen]
+	verifier := payload[aesSaltLen : aesSaltLen+2]
+	enc := payload[aesSaltLen+2 : len(payload)-aesAuthLen]
+	auth := payload[len(payload)-aesAuthLen:]
+
+	// パスワードを確認して、認証コードで改ざん・破損を確認する
+	keys := pbkdf2SHA1([]byte(password), salt, aesIterations, aesKeyLen*2+2)
+	if !hmac.Equal(keys[aesKeyLen*2:], verifier) {
+		return nil, errors.New("暗号化ZIPのパスワードが違います")
+	}
+	mac := hmac.New(sha1.New, keys[aesKeyLen:aesKeyLen*2])
+	mac.Write(enc)
+	if !hmac.Equal(mac.Sum(nil)[:aesAuthLen], auth) {
+		return nil, errors.New("暗号化ZIPのパスワードが違うか、ファイルが壊れています")
+	}
+
+	block, err := aes.NewCipher(keys[:aesKeyLen])
+	if err != nil {
+		return nil, err
+	}
+	plain := make([]byte, len(enc))
+	newAECTR(block).XORKeyStream(plain, enc)
+
+	data := plain
+	switch method {
+	case zip.Store:
+	case zip.Deflate:
+		if data, err = io.ReadAll(flate.NewReader(bytes.NewReader(plain))); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("暗号化ZIPの圧縮方式[%d]は読めません", method)
+	}
+	if uint64(len(data)) != f.UncompressedSize64 {
+		return nil, errors.New("暗号化ZIPのファイルの大きさが違います")
+	}
+
+	return data, nil
+}
+
+func pbkdf2SHA1(password []byte, salt []byte, iter int, keyLen int) []byte {
+	// PBKDF2(HMAC-SHA1)で鍵を作る(RFC 2898)
+
+	prf := hmac.New(sha1.New, password)
+	hashLen := prf.Size()
+	blocks := (keyLen + hashLen - 1) / hashLen
+
+	dk := make([]byte, 0, blocks*hashLen)
+	u := make([]byte, hashLen)
+	for i := 1; i <= blocks; i++ {
+		prf.Reset()
+		prf.Write(salt)
+		prf.Write([]byte{byte(i >> 24), byte(i >> 16), byte(i >> 8), byte(i)})
+		dk = prf.Sum(dk)
+		t := dk[len(dk)-hashLen:]
+		copy(u, t)
+
+		for n := 2; n <= iter; n++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range u {
+				t[j] ^= u[j]
+			}
+		}
+	}
+
+	return dk[:keyLen]
+}
+
+// aeCTR はWinZipのAESで使うCTRモード
+// カウンタは1から始まるリトルエンディアン（crypto/cipher のCTRはビッグエンディアンなので使えない）
+type aeCTR struct {
+	block   cipher.Block
+	counter [aes.BlockSize]byte
+	stream  [aes.BlockSize]byte
+	pos     int
+}
+
+func newAECTR(block cipher.Block) *aeCTR {
+	return &aeCTR{block: block, pos: aes.BlockSize}
+}
+
+func (c *aeCTR) XORKeyStream(dst []byte, src []byte) {
+	for i := range src {
+		if c.pos == aes.BlockSize {
+			for j := range c.counter {
+				c.counter[j]++
+				if c.counter[j] != 0 {
+					break
+				}
+			}
+			c.block.Encrypt(c.stream[:], c.counter[:])
+			c.pos = 0
+		}
+		dst[i] = src[i] ^ c.stream[c.pos]
+		c.pos++
+	}
+}
+
+func dosTime(t time.Time) (uint16, uint16) {
+	// MS-DOS形式の日付と時刻を返す
+
+	if t.Year() < 1980 {
+		t = time.Date(1980, 1, 1, 0, 0, 0, 0, t.Location())
+	}
+	date := uint16(t.Day() + int(t.Month())<<5 + (t.Year()-1980)<<9)
+	clock := uint16(t.Second()/2 + t.Minute()<<5 + t.Hour()<<11)
+
+	return date, clock
+}
@@ -0,0 +1,87 @@
+package ricohsanai
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/hex"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestPbkdf2SHA1(t *testing.T) {
+	// RFC 6070 のテストベクタ
+
+	tests := []struct {
+		iter int
+		want string
+	}{
+		{1, "0c60c80f961f0e71f3a9b524af6012062fe037a6"},
+		{2, "ea6c014dc72d6f8ccd1ed92ace1d41f0d8de8957"},
+		{4096, "4b007901b765489abead49d926f721d065a429c1"},
+	}
+	for _, tt := range tests {
+		got := hex.EncodeToString(pbkdf2SHA1([]byte("password"), []byte("salt"), tt.iter, 20))
+		if got != tt.want {
+			t.Errorf("pbkdf2SHA1(iter=%d) = %s, want %s", tt.iter, got, tt.want)
+		}
+	}
+}
+
+func TestWriteEncryptedZip(t *testing.T) {
+	// 暗号化したZIPをパスワードで復号して元のデータに戻ることを確認する
+
+	data := bytes.Repeat([]byte("受診番号,氏名,受診日\r\n100000,山田　太郎,2024/06/11\r\n"), 100)
+	modTime := time.Date(2024, 6, 11, 10, 20, 30, 0, time.Local)
+
+	var buf bytes.Buffer
+	if err := WriteEncryptedZip(&buf, "健診データ.csv", data, modTime, "RS-test1"); err != nil {
+		t.Fatal(err)
+	}
+	zipdata := buf.Bytes()
+
+	zr, err := zip.NewReader(bytes.NewReader(zipdata), int64(len(zipdata)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(zr.File) != 1 {
+		t.Fatalf("ファイル数 %d", len(zr.File))
+	}
+	f := zr.File[0]
+	if f.Name != "健診データ.csv" {
+		t.Errorf("ファイル名 %q", f.Name)
+	}
+	if f.Method != aesMethod || f.Flags&0x1 == 0 {
+		t.Errorf("暗号化されていません(Method=%d Flags=%#x)", f.Method, f.Flags)
+	}
+	if got := f.Modified; got.Year() != 2024 || got.Month() != 6 || got.Day() != 11 || got.Hour() != 10 || got.Minute() != 20 {
+		t.Errorf("更新日時 %s", got)
+	}
+
+	got, err := ReadEncryptedZip(bytes.NewReader(zipdata), int64(len(zipdata)), "RS-test1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("復号したデータが違います(%d バイト, want %d バイト)", len(got), len(data))
+	}
+
+	if _, err := ReadEncryptedZip(bytes.NewReader(zipdata), int64(len(zipdata)), "RS-test2"); err == nil {
+		t.Error("違うパスワードで復号できました")
+	}
+
+	// 暗号化したデータが壊れていれば認証コードでエラーになる
+	broken := append([]byte(nil), zipdata...)
+	off, err := f.DataOffset()
+	if err != nil {
+		t.Fatal(err)
+	}
+	broken[off+aesSaltLen+2] ^= 0xff
+	if _, err := ReadEncryptedZip(bytes.NewReader(broken), int64(len(broken)), "RS-test1"); err == nil {
+		t.Error("壊れたZIPを復号できました")
+	}
+
+	if err := WriteEncryptedZip(io.Discard, "a.csv", data, modTime, ""); err == nil {
+		t.Error("パスワードが空でもエラーになりません")
+	}
+}
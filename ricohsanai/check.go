@@ -0,0 +1,87 @@
+package ricohsanai
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// checkpoints は健保側で列ずれの確認に使うカンマ位置の列
+var checkpoints = []int{131, 331, 382, 432, 540}
+
+func VerifyRow(row []string) error {
+	// 列数とカンマ位置を確認し、ずれている区間をエラーで返す
+
+	var msg []string
+
+	if len(row) != checkpoints[len(checkpoints)-1] {
+		msg = append(msg, fmt.Sprintf("列数が%d列ではありません(%d列)", checkpoints[len(checkpoints)-1], len(row)))
+	}
+
+	// 区間ごとのずれを確認する（前の区間から引き継いだずれは報告しない）
+	from := 1
+	shift := 0
+	for _, cp := range checkpoints {
+		want := strconv.Itoa(cp)
+		if cp <= len(row) && row[cp-1] == want {
+			// 前の区間のずれをこの区間で打ち消している時もこの区間はずれている
+			if shift != 0 {
+				msg = append(msg, fmt.Sprintf("%d～%d列目の区間で%+d列ずれています(カンマ位置(%d)が%d列目にあります)", from, cp, -shift, cp, cp))
+			}
+			shift = 0
+		} else if pos := findCheckpoint(row, from-1+shift, want); pos < 0 {
+			msg = append(msg, fmt.Sprintf("%d～%d列目の区間で列がずれています(カンマ位置(%d)が見つかりません)", from, cp, cp))
+		} else if pos-(cp-1) != shift {
+			msg = append(msg, fmt.Sprintf("%d～%d列目の区間で%+d列ずれています(カンマ位置(%d)が%d列目にあります)", from, cp, pos-(cp-1)-shift, cp, pos+1))
+			shift = pos - (cp - 1)
+		}
+		from = cp + 1
+	}
+
+	if len(msg) > 0 {
+		return fmt.Errorf("列位置エラー: %s", strings.Join(msg, "、"))
+	}
+
+	return nil
+}
+
+func findCheckpoint(row []string, start int, want string) int {
+	// start 列目以降で最初に want がある列を返す(無ければ -1)
+
+	if start < 0 {
+		start = 0
+	}
+
+	for i := start; i < len(row); i++ {
+		if row[i] == want {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func verifyColumns() error {
+	// 列定義のカンマ位置と抽出データの項目名を確認する
+
+	title := Title()
+	for _, cp := range checkpoints {
+		want := fmt.Sprintf("カンマ位置(%d)", cp)
+		if cp > len(title) || title[cp-1] != want {
+			return fmt.Errorf("列定義エラー: [%s]が%d列目にありません", want, cp)
+		}
+	}
+
+	if len(title) != checkpoints[len(checkpoints)-1] {
+		return fmt.Errorf("列定義エラー: 列数が%d列ではありません(%d列)", checkpoints[len(checkpoints)-1], len(title))
+	}
+
+	lay := defaultLayout()
+	for _, col := range columns {
+		if miss := lay.missing(col.src); len(miss) > 0 {
+			return fmt.Errorf("列定義エラー: [%s]の抽出データの項目%sが定義されていません", col.title, miss)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package ricohsanai
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVerifyRow(t *testing.T) {
+	// カンマ位置がずれた区間だけを報告する（前の区間から引き継いだずれは報告しない）
+
+	if err := verifyColumns(); err != nil {
+		t.Fatal(err)
+	}
+
+	header, rows := readTestRows(t)
+	c := testConverter()
+	if _, err := c.SetHeader(header); err != nil {
+		t.Fatal(err)
+	}
+	row, _ := c.ConvertRecord(rows[0])
+	if err := VerifyRow(row); err != nil {
+		t.Fatalf("変換した行: %v", err)
+	}
+
+	drop := func(row []string, i int) []string {
+		return append(append([]string(nil), row[:i]...), row[i+1:]...)
+	}
+	insert := func(row []string, i int) []string {
+		return append(append(append([]string(nil), row[:i]...), ""), row[i:]...)
+	}
+
+	tests := []struct {
+		name     string
+		row      []string
+		sections int
+		want     []string
+	}{
+		{"1区間目の列が足りない", drop(row, 99), 1, []string{"列数が540列ではありません(539列)", "1～131列目の区間で-1列ずれています(カンマ位置(131)が130列目にあります)"}},
+		{"2区間目の列が多い", insert(row, 199), 1, []string{"列数が540列ではありません(541列)", "132～331列目の区間で+1列ずれています(カンマ位置(331)が332列目にあります)"}},
+		{"ずれて元に戻る", insert(drop(row, 99), 150), 2, []string{"1～131列目の区間で-1列ずれています", "132～331列目の区間で+1列ずれています"}},
+		{"カンマ位置が無い", setAt(row, 381, ""), 1, []string{"332～382列目の区間で列がずれています(カンマ位置(382)が見つかりません)"}},
+	}
+	for _, tt := range tests {
+		err := VerifyRow(tt.row)
+		if err == nil {
+			t.Errorf("%s: エラーになりません", tt.name)
+			continue
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("%s: %v\nwant %s", tt.name, err, want)
+			}
+		}
+		if n := strings.Count(err.Error(), "区間"); n != tt.sections {
+			t.Errorf("%s: 報告した区間が多すぎます: %v", tt.name, err)
+		}
+	}
+}
+
+func setAt(row []string, i int, value string) []string {
+	// row の i 列目を value にしたコピーを返す
+
+	row = append([]string(nil), row...)
+	row[i] = value
+
+	return row
+}
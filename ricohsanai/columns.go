@@ -0,0 +1,1122 @@
+package ricohsanai
+
+import (
+	"fmt"
+	"strings"
+)
+
+// column は出力CSVの１列を表す
+// conv を省略した場合は src の先頭の値をそのまま出力する（src も無ければ空欄）
+type column struct {
+	title string   // タイトル行の項目名
+	src   []string // 抽出データの項目名
+	conv  convFunc // 変換処理
+	limit int      // 最大バイト数(shift-JIS)
+	req   string   // 必須項目の名称
+}
+
+type convFunc func(rec *record, v []string) (string, error)
+
+// 既往歴の病名と転帰の列
+var kiouSrc = in(
+	"既往歴1", "既往歴1転帰",
+	"既往歴2", "既往歴2転帰",
+	"既往歴3", "既往歴3転帰",
+	"既往歴4", "既往歴4転帰",
+	"既往歴5", "既往歴5転帰",
+	"既往歴6", "既往歴6転帰",
+	"既往歴7", "既往歴7転帰",
+	"既往歴8", "既往歴8転帰",
+	"既往歴9", "既往歴9転帰",
+	"既往歴10", "既往歴10転帰",
+)
+
+// [Met]具体的な既往歴の病名、年齢、転帰の列
+var metKiouSrc = in(
+	"既往歴1", "既往歴1年齢", "既往歴1転帰",
+	"既往歴2", "既往歴2年齢", "既往歴2転帰",
+	"既往歴3", "既往歴3年齢", "既往歴3転帰",
+	"既往歴4", "既往歴4年齢", "既往歴4転帰",
+	"既往歴5", "既往歴5年齢", "既往歴5転帰",
+	"既往歴6", "既往歴6年齢", "既往歴6転帰",
+	"既往歴7", "既往歴7年齢", "既往歴7転帰",
+	"既往歴8", "既往歴8年齢", "既往歴8転帰",
+	"既往歴9", "既往歴9年齢", "既往歴9転帰",
+	"既往歴10", "既往歴10年齢", "既往歴10転帰",
+)
+
+// 総合判定コメントの元になる判定とコメントの列
+var sogoSrc = in(
+	"総合判定", "総合判定コメント",
+	"BMI判定", "BMIコメント",
+	"体脂肪測定判定", "体脂肪測定コメント",
+	"聴力判定", "聴力コメント",
+	"視力判定", "視力コメント",
+	"肺機能判定", "肺機能コメント",
+	"肺年齢判定", "肺年齢コメント",
+	"血圧判定", "血圧コメント",
+	"尿糖判定", "尿糖コメント",
+	"蛋白判定", "蛋白コメント",
+	"ウロビリ判定", "ウロビリコメント",
+	"潜血判定", "潜血コメント",
+	"尿比重判定", "尿比重コメント",
+	"尿PH判定", "尿PHコメント",
+	"尿沈渣判定", "尿沈渣コメント",
+	"胸部X線判定", "胸部X線コメント",
+	"喀痰判定", "喀痰コメント",
+	"心電図判定", "心電図コメント",
+	"貧血判定", "貧血コメント",
+	"血小板判定", "血小板コメント",
+	"白血球判定", "白血球コメント",
+	"白血球像判定", "白血球像コメント",
+	"肝機能判定", "肝機能コメント",
+	"膵機能判定", "膵機能コメント",
+	"血中脂質判定", "血中脂質コメント",
+	"腎機能判定", "腎機能コメント",
+	"腎機能コメント判定", "腎機能コメントコメント",
+	"血清尿酸判定", "血清尿酸コメント",
+	"糖代謝判定", "糖代謝コメント",
+	"電解質判定", "電解質コメント",
+	"眼底判定", "眼底コメント",
+	"眼圧判定", "眼圧コメント",
+	"胃部X線判定", "胃部X線コメント",
+	"胃内視鏡判定", "胃内視鏡コメント",
+	"胃内視鏡生検判定", "胃内視鏡生検コメント",
+	"腹部エコー判定", "腹部エコーコメント",
+	"便判定", "便コメント",
+	"便虫卵判定", "便虫卵コメント",
+	"CRP判定", "CRPコメント",
+	"リウマチ判定", "リウマチコメント",
+	"ピロリ菌判定", "ピロリ菌コメント",
+	"PG検査判定", "PG検査コメント",
+	"腫瘍マーカー判定", "腫瘍マーカーコメント",
+	"甲状腺判定", "甲状腺コメント",
+	"梅毒判定", "梅毒コメント",
+	"BNP判定", "BNPコメント",
+	"乳腺超音波判定", "乳腺超音波コメント",
+	"マンモグラフィー判定", "マンモグラフィーコメント",
+	"婦人科内診判定", "婦人科内診コメント",
+	"子宮細胞診判定", "子宮細胞診コメント",
+	"骨密度判定", "骨密度コメント",
+	"心エコー判定", "心エコーコメント",
+	"血圧脈波判定", "血圧脈波コメント",
+	"頸動脈エコー判定", "頸動脈エコーコメント",
+	"甲状腺エコー判定", "甲状腺エコーコメント",
+	"内科診察判定", "内科診察コメント",
+	"腹部CT判定", "腹部CTコメント",
+	"治療中判定", "治療中コメント",
+)
+
+// columns は出力CSVの列定義
+// タイトル行もデータ行もこの定義から作成する
+var columns = []column{
+	{title: "CSVフォーマットVer", conv: fixed("RB_Ver.1.0")},
+	{title: "提出先", conv: profile("提出先")},
+	{title: "データ作成者", conv: profile("データ作成者")},
+	{title: "データ作成日", conv: createdDay},
+	{title: "データ提出日", conv: submitDay},
+	{title: "データ登録完了区分", conv: kanryoKubun},
+	{title: "登録未完了の連絡内容", conv: mikanryoMsg},
+	{title: "団体コード", conv: profile("団体コード")},
+	{title: "団体コード名称", src: in("所属名1"), req: "所属名1"},
+	{title: "事業所コード", src: in("所属cd2"), req: "所属cd2"},
+	{title: "事業所名称", src: in("所属名2"), req: "所属名2"},
+	{title: "個人ID", src: in("社員No"), conv: kojinId},
+	{title: "漢字氏名", src: in("漢字氏名"), req: "漢字氏名"},
+	{title: "カナ氏名", src: in("カナ氏名"), req: "カナ氏名"},
+	{title: "生年月日", src: in("生年月日"), conv: birthDay, req: "生年月日"},
+	{title: "性別", src: in("性別"), conv: one(seiConv), req: "性別"},
+	{title: "保険者番号", src: in("保険者番号"), req: "保険者番号"},
+	{title: "保険証記号", src: in("保険証記号"), req: "保険証記号"},
+	{title: "保険証番号", src: in("保険証番号"), req: "保険証番号"},
+	{title: "続柄"},
+	{title: "予備"},
+	{title: "予備"},
+	{title: "受診券整理番号", src: in("受診券整理番号")},
+	{title: "受診券有効期限", src: in("受診券有効期限"), conv: kigenDay},
+	{title: "コースコード", src: in("コースコード", "コース名", "年齢", "受診日"), conv: courseCd},
+	{title: "コース名称", src: in("コースコード", "コース名", "年齢", "受診日"), conv: courseName},
+	{title: "受診日", src: in("受診日"), conv: jusinDay, req: "受診日"},
+	{title: "施設/巡回区分", src: in("施設/巡回区分"), conv: one(sisetsuConv), req: "施設/巡回区分"},
+	{title: "健診機関コード"},
+	{title: "健診機関名称", conv: profile("健診機関名称")},
+	{title: "[Met]特定健診機関番号", conv: profile("特定健診機関番号")},
+	{title: "[Met]健診実施医師名", conv: profile("健診実施医師名")},
+	{title: "予備"},
+	{title: "予備"},
+	{title: "産業医判定区分"},
+	{title: "就労区分"},
+	{title: "産業医コメント"},
+	{title: "伝達事項有無"},
+	{title: "伝達内容"},
+	{title: "診察判定区分コード", src: in("内科診察判定"), conv: hanteiCd},
+	{title: "診察判定区分名称", src: in("内科診察判定"), conv: hanteiName},
+	{title: "（予備）留意所見有無区分"},
+	{title: "診察所見", src: in("診察所見1", "診察所見2", "診察所見3"), conv: join, limit: 100},
+	{title: "自覚症状など", src: in("自覚症状1", "自覚症状2", "自覚症状3", "自覚症状4", "自覚症状5"), conv: join, limit: 100},
+	{title: "治療中疾病有無区分", src: kiouSrc, conv: chiryoUmu},
+	{title: "治療中疾病名（文字）", src: kiouSrc, conv: chiryoName, limit: 100},
+	{title: "既往疾病有無区分", src: kiouSrc, conv: kiouUmu},
+	{title: "既往疾病名", src: kiouSrc, conv: kiouName, limit: 100},
+	{title: "総合判定区分コード", src: in("総合判定"), conv: hanteiCd},
+	{title: "総合判定区分名称", src: in("総合判定"), conv: hanteiName},
+	{title: "総合判定コメント", src: sogoSrc, conv: sogo, limit: 1200},
+	{title: "予備"},
+	{title: "予備"},
+	{title: "予備①(1)"},
+	{title: "予備②(1)"},
+	{title: "予備③(1)"},
+	{title: "予備①(2)"},
+	{title: "予備②(2)"},
+	{title: "予備③(2)"},
+	{title: "予備①(3)"},
+	{title: "予備②(3)"},
+	{title: "予備③(3)"},
+	{title: "予備①(4)"},
+	{title: "予備②(4)"},
+	{title: "予備③(4)"},
+	{title: "予備①(5)"},
+	{title: "予備②(5)"},
+	{title: "予備③(5)"},
+	{title: "予備①(6)"},
+	{title: "予備②(6)"},
+	{title: "予備③(6)"},
+	{title: "予備①(7)"},
+	{title: "予備②(7)"},
+	{title: "予備③(7)"},
+	{title: "予備①(8)"},
+	{title: "予備②(8)"},
+	{title: "予備③(8)"},
+	{title: "予備①(9)"},
+	{title: "予備②(9)"},
+	{title: "予備③(9)"},
+	{title: "予備①(10)"},
+	{title: "予備②(10)"},
+	{title: "予備③(10)"},
+	{title: "予備①(11)"},
+	{title: "予備②(11)"},
+	{title: "予備③(11)"},
+	{title: "予備①(12)"},
+	{title: "予備②(12)"},
+	{title: "予備③(12)"},
+	{title: "予備①(13)"},
+	{title: "予備②(13)"},
+	{title: "予備③(13)"},
+	{title: "予備①(14)"},
+	{title: "予備②(14)"},
+	{title: "予備③(14)"},
+	{title: "予備①(15)"},
+	{title: "予備②(15)"},
+	{title: "予備③(15)"},
+	{title: "予備①(16)"},
+	{title: "予備②(16)"},
+	{title: "予備③(16)"},
+	{title: "予備①(17)"},
+	{title: "予備②(17)"},
+	{title: "予備③(17)"},
+	{title: "予備①(18)"},
+	{title: "予備②(18)"},
+	{title: "予備③(18)"},
+	{title: "予備①(19)"},
+	{title: "予備②(19)"},
+	{title: "予備③(19)"},
+	{title: "予備①(20)"},
+	{title: "予備②(20)"},
+	{title: "予備③(20)"},
+	{title: "予備①(21)"},
+	{title: "予備②(21)"},
+	{title: "予備③(21)"},
+	{title: "予備①(22)"},
+	{title: "予備②(22)"},
+	{title: "予備③(22)"},
+	{title: "予備①(23)"},
+	{title: "予備②(23)"},
+	{title: "予備③(23)"},
+	{title: "予備①(24)"},
+	{title: "予備②(24)"},
+	{title: "予備③(24)"},
+	{title: "予備"},
+	{title: "予備"},
+	{title: "その他判定区分コード"},
+	{title: "その他判定区分名称"},
+	{title: "その他データ内容"},
+	{title: "カンマ位置(131)", conv: fixed("131")},
+	{title: "身長", src: in("身長")},
+	{title: "体重", src: in("体重")},
+	{title: "BMI", src: in("BMI")},
+	{title: "腹囲", src: in("腹囲")},
+	{title: "体脂肪率", src: in("体脂肪率")},
+	{title: "内臓脂肪面積"},
+	{title: "5m視力裸眼右", src: in("視力裸眼右"), conv: eyeValue},
+	{title: "　データ属性", src: in("視力裸眼右"), conv: eyeCode},
+	{title: "5m視力裸眼左", src: in("視力裸眼左"), conv: eyeValue},
+	{title: "　データ属性", src: in("視力裸眼左"), conv: eyeCode},
+	{title: "5m視力矯正右", src: in("視力矯正右"), conv: eyeValue},
+	{title: "　データ属性", src: in("視力矯正右"), conv: eyeCode},
+	{title: "5m視力矯正左", src: in("視力矯正左"), conv: eyeValue},
+	{title: "　データ属性", src: in("視力矯正左"), conv: eyeCode},
+	{title: "近点視力裸眼右", src: in("近点視力裸眼右"), conv: eyeValue},
+	{title: "　データ属性", src: in("近点視力裸眼右"), conv: eyeCode},
+	{title: "近点視力裸眼左", src: in("近点視力裸眼左"), conv: eyeValue},
+	{title: "　データ属性", src: in("近点視力裸眼左"), conv: eyeCode},
+	{title: "近点視力矯正右", src: in("近点視力矯正右"), conv: eyeValue},
+	{title: "　データ属性", src: in("近点視力矯正右"), conv: eyeCode},
+	{title: "近点視力矯正左", src: in("近点視力矯正左"), conv: eyeValue},
+	{title: "　データ属性", src: in("近点視力矯正左"), conv: eyeCode},
+	{title: "視力矯正区分", src: in("視力矯正右", "視力矯正左", "近点視力矯正右", "近点視力矯正左"), conv: eyeKubunConv},
+	{title: "聴力右1K所見区分", src: in("聴力右1000Hz判定"), conv: one(ear1kHantei)},
+	{title: "聴力右1K(dB)", src: in("聴力右1000Hz"), conv: earDB},
+	{title: "聴力左1K所見区分", src: in("聴力左1000Hz判定"), conv: one(ear1kHantei)},
+	{title: "聴力左1K(dB)", src: in("聴力左1000Hz"), conv: earDB},
+	{title: "聴力右4K所見区分", src: in("聴力右4000Hz判定", "聴力右4000Hz判定2"), conv: ear4k},
+	{title: "聴力右4K(dB)", src: in("聴力右4000Hz", "聴力右4000Hz2"), conv: earDB},
+	{title: "聴力左4K所見区分", src: in("聴力左4000Hz判定", "聴力左4000Hz判定2"), conv: ear4k},
+	{title: "聴力左4K(dB)", src: in("聴力左4000Hz", "聴力左4000Hz2"), conv: earDB},
+	{title: "聴力会話法", src: in("聴力所見", "聴力判定"), conv: kaiwa},
+	{title: "聴力所見（文字）", src: in("聴力所見")},
+	{title: "収縮期血圧（報告値）", src: in("血圧1回目最高判定", "血圧1回目最低判定", "血圧2回目最高判定", "血圧2回目最低判定", "最高血圧1回目", "最高血圧2回目"), conv: ketsuatuH},
+	{title: "拡張期血圧（報告値）", src: in("血圧1回目最高判定", "血圧1回目最低判定", "血圧2回目最高判定", "血圧2回目最低判定", "最低血圧1回目", "最低血圧2回目"), conv: ketsuatuL},
+	{title: "収縮期血圧1回目", src: in("最高血圧1回目")},
+	{title: "拡張期血圧1回目", src: in("最低血圧1回目")},
+	{title: "収縮期血圧2回目", src: in("最高血圧2回目")},
+	{title: "拡張期血圧2回目", src: in("最低血圧2回目")},
+	{title: "脈拍数"},
+	{title: "心電図実施区分"},
+	{title: "心電図未実施理由"},
+	{title: "心電図判定区分コード", src: in("心電図判定"), conv: hanteiCd},
+	{title: "心電図判定区分名称", src: in("心電図判定"), conv: hanteiName},
+	{title: "（予備）留意所見有無区分"},
+	{title: "心電図所見（文字）", src: in("心電図所見1", "心電図所見2", "心電図所見3", "心電図所見4", "心電図所見5"), conv: join, limit: 256},
+	{title: "心拍数", src: in("心拍数")},
+	{title: "[Met]心電図所見有無", src: in("心電図判定"), conv: one(syokenUmu)},
+	{title: "[Met]心電図対象者", src: in("心電図判定"), conv: taisyoConv},
+	{title: "[Met]心電図実施理由"},
+	{title: "胸部X線実施区分"},
+	{title: "胸部X線未実施理由"},
+	{title: "胸部X線撮影区分", src: in("胸部X線間接", "胸部X線直接"), conv: satsueiConv},
+	{title: "胸部X線判定区分コード", src: in("胸部X線判定"), conv: hanteiCd},
+	{title: "胸部X線判定区分名称", src: in("胸部X線判定"), conv: hanteiName},
+	{title: "（予備）留意所見有無区分"},
+	{title: "胸部X線部位・所見（文字）", src: in("胸部X線所見1", "胸部X線所見2", "胸部X線所見3", "胸部X線所見4", "胸部X線所見5"), conv: join, limit: 240},
+	{title: "心胸比"},
+	{title: "[Met]胸部X線所見有無", src: in("胸部X線判定"), conv: one(syokenUmu)},
+	{title: "胸部CT実施区分"},
+	{title: "胸部CT未実施理由"},
+	{title: "胸部CT判定区分コード", src: in("胸部CT", "腹部CT判定"), conv: ctHanteiCd},
+	{title: "胸部CT判定区分名称", src: in("胸部CT", "腹部CT判定"), conv: ctHanteiName},
+	{title: "（予備）留意所見有無区分"},
+	{title: "胸部CT部位・所見（文字）", src: in("胸部CT", "胸部CT所見1", "胸部CT所見2", "胸部CT所見3", "胸部CT所見4"), conv: ctSyoken, limit: 240},
+	{title: "喀痰実施区分"},
+	{title: "喀痰未実施理由"},
+	{title: "喀痰判定区分コード", src: in("喀痰細胞診"), conv: kakutanCd},
+	{title: "喀痰判定区分名称", src: in("喀痰細胞診"), conv: kakutanName},
+	{title: "喀痰細胞診結果", src: in("喀痰細胞診"), conv: kakutanKekka},
+	{title: "喀痰細胞診所見（文字）"},
+	{title: "《予備》喀痰（抗酸菌）"},
+	{title: "《予備》喀痰培養（ガフキー）"},
+	{title: "肺活量", src: in("肺活量")},
+	{title: "１秒量", src: in("１秒量")},
+	{title: "努力肺活量", src: in("努力肺活量")},
+	{title: "１秒率", src: in("１秒率")},
+	{title: "％肺活量", src: in("％肺活量")},
+	{title: "％１秒量", src: in("％１秒量")},
+	{title: "肺機能換気障害区分"},
+	{title: "眼底実施区分"},
+	{title: "眼底未実施理由"},
+	{title: "眼底判定区分", src: in("眼底判定"), conv: hanteiCd},
+	{title: "眼底判定区分名称", src: in("眼底判定"), conv: hanteiName},
+	{title: "眼底右シェイエ", src: in("眼底右S", "眼底右H"), conv: scheie},
+	{title: "眼底左シェイエ", src: in("眼底左S", "眼底右KW"), conv: scheie},
+	{title: "予備（眼底）"},
+	{title: "予備（眼底）"},
+	{title: "眼底右Scott", src: in("眼底右Scott"), conv: one(scottConv)},
+	{title: "眼底左Scott", src: in("眼底左Scott"), conv: one(scottConv)},
+	{title: "眼底右KW", src: in("眼底右KW"), conv: one(kwConv)},
+	{title: "眼底左KW", src: in("眼底左KW"), conv: one(kwConv)},
+	{title: "眼底右Wong-Mitchell"},
+	{title: "眼底左Wong-Mitchell"},
+	{title: "眼底右Davis"},
+	{title: "眼底左Davis"},
+	{title: "眼底右その他所見（文字）", src: in("眼底所見1", "眼底所見2", "眼底所見3", "眼底所見4", "眼底所見5"), conv: joinTrim, limit: 256},
+	{title: "眼底左その他所見（文字）"},
+	{title: "[Met]眼底検査（対象者）", src: in("眼底判定"), conv: taisyoConv},
+	{title: "[Met]眼底検査（実施理由）"},
+	{title: "予備"},
+	{title: "眼圧右", src: in("眼圧右")},
+	{title: "眼圧左", src: in("眼圧左")},
+	{title: "腹部超音波実施区分"},
+	{title: "腹部超音波未実施理由"},
+	{title: "腹部超音波判定区分コード", src: in("腹部エコー判定"), conv: hanteiCd},
+	{title: "腹部超音波判定区分名称", src: in("腹部エコー判定"), conv: hanteiName},
+	{title: "（予備）留意所見有無区分"},
+	{title: "腹部超音波部位・所見（文字）", src: in("腹部超音波所見1", "腹部超音波所見2", "腹部超音波所見3", "腹部超音波所見4", "腹部超音波所見5", "腹部超音波所見6", "腹部超音波所見7"), conv: join, limit: 240},
+	{title: "尿糖定性", src: in("尿糖"), conv: one(teiseiConv)},
+	{title: "尿蛋白定性", src: in("尿蛋白"), conv: one(teiseiConv)},
+	{title: "尿潜血定性", src: in("尿潜血"), conv: one(teiseiConv)},
+	{title: "尿ウロビリノーゲン定性", src: in("ウロビリノーゲン"), conv: one(teiseiConv)},
+	{title: "尿比重", src: in("尿比重")},
+	{title: "尿pH", src: in("尿PH")},
+	{title: "尿沈渣判定区分コード", src: in("尿沈渣判定"), conv: hanteiCd},
+	{title: "尿沈渣判定区分名称", src: in("尿沈渣判定"), conv: hanteiName},
+	{title: "尿沈渣赤血球", src: in("尿沈渣赤血球")},
+	{title: "尿沈渣白血球", src: in("尿沈渣白血球")},
+	{title: "尿沈渣扁平上皮", src: in("尿沈渣扁平上皮")},
+	{title: "尿沈渣顆粒円柱", src: in("尿沈渣顆粒円柱")},
+	{title: "尿沈渣ガラス円柱", src: in("尿沈渣ガラス円柱")},
+	{title: "尿沈渣細菌", src: in("尿沈渣その他1", "尿沈渣その他2", "尿沈渣その他3"), conv: saikin},
+	{title: "尿沈渣その他", src: in("尿沈渣その他1", "尿沈渣その他2", "尿沈渣その他3"), conv: chinsaSonota},
+	{title: "赤血球数", src: in("赤血球数"), conv: one(numChk)},
+	{title: "血色素量", src: in("血色素量"), conv: one(numChk)},
+	{title: "ヘマトクリット", src: in("ヘマトクリット"), conv: one(numChk)},
+	{title: "白血球数", src: in("白血球数"), conv: one(numChk)},
+	{title: "血小板数", src: in("血小板数"), conv: one(numChk)},
+	{title: "MCV", src: in("MCV"), conv: one(numChk)},
+	{title: "MCH", src: in("MCH"), conv: one(numChk)},
+	{title: "MCHC", src: in("MCHC"), conv: one(numChk)},
+	{title: "[Met]貧血検査（実施理由）"},
+	{title: "血液像判定区分コード", src: in("白血球像判定"), conv: hanteiCd},
+	{title: "血液像判定区分名称", src: in("白血球像判定"), conv: hanteiName},
+	{title: "好中球(Neut)", src: in("Neut"), conv: one(numChk)},
+	{title: "棹状核球(Stab)", src: in("Stab"), conv: one(numChk)},
+	{title: "分葉核球(Seg)", src: in("Seg"), conv: one(numChk)},
+	{title: "好酸球(Eosino)", src: in("Eosino"), conv: one(numChk)},
+	{title: "好塩基球(Baso)", src: in("Baso"), conv: one(numChk)},
+	{title: "リンパ球(Lympho)", src: in("Lympho"), conv: one(numChk)},
+	{title: "単球(Mono)", src: in("Mono"), conv: one(numChk)},
+	{title: "異形リンパ球(A-Lympho)"},
+	{title: "骨髄球(Myelo)"},
+	{title: "後骨髄球(Meta)"},
+	{title: "白血球分画その他"},
+	{title: "その他の内容", src: in("白血球像その他1", "白血球像その他2"), conv: join},
+	{title: "血清鉄", src: in("血清鉄"), conv: one(numChk)},
+	{title: "フェリチン", src: in("フェリチン"), conv: one(numChk)},
+	{title: "血液型ABO", src: in("血液型ABO"), conv: one(aboConv)},
+	{title: "血液型Rh", src: in("血液型Rh"), conv: one(rhConv)},
+	{title: "食後時間区分", src: in("血糖", "食後時間"), conv: eatTime},
+	{title: "生理区分", src: in("生理中"), conv: one(seiriConv)},
+	{title: "妊娠区分", src: in("妊娠中", "妊娠の可能性"), conv: ninshin},
+	{title: "乳び", src: in("検体コメント1", "検体コメント2"), conv: nyubi},
+	{title: "溶血", src: in("検体コメント1", "検体コメント2"), conv: yoketsu},
+	{title: "血清総蛋白", src: in("総蛋白"), conv: one(numChk)},
+	{title: "血清アルブミン", src: in("アルブミン"), conv: one(numChk)},
+	{title: "A/G比", src: in("A/G比"), conv: one(numChk)},
+	{title: "尿中アルブミン"},
+	{title: "AST(GOT)", src: in("AST(GOT)"), conv: one(numChk)},
+	{title: "ALT(GPT)", src: in("ALT(GPT)"), conv: one(numChk)},
+	{title: "γ-GTP", src: in("γ-GTP"), conv: one(numChk)},
+	{title: "ALP", src: in("ALP"), conv: one(numChk)},
+	{title: "LDH", src: in("LDH"), conv: one(numChk)},
+	{title: "コリンエステラーゼ", src: in("コリンエステラーゼ"), conv: one(numChk)},
+	{title: "LAP", src: in("LAP"), conv: one(numChk)},
+	{title: "総ビリルビン", src: in("総ビリルビン"), conv: one(numChk)},
+	{title: "直接ビリルビン", src: in("直接ビリルビン"), conv: one(numChk)},
+	{title: "CPK", src: in("CPK"), conv: one(numChk)},
+	{title: "　レベル区分"},
+	{title: "BNP", src: in("BNP"), conv: one(numChk)},
+	{title: "　レベル区分"},
+	{title: "総コレステロール", src: in("総コレステロール"), conv: one(numChk)},
+	{title: "HDLコレステロール", src: in("HDLコレステロール"), conv: one(numChk)},
+	{title: "LDLコレステロール", src: in("LDLコレステロール"), conv: one(numChk)},
+	{title: "中性脂肪", src: in("中性脂肪"), conv: one(numChk)},
+	{title: "non-HDLコレステロール", src: in("non-HDLコレステロール"), conv: one(numChk)},
+	{title: "空腹時血糖", src: in("血糖", "食後時間"), conv: kufuku},
+	{title: "随時血糖", src: in("血糖", "食後時間"), conv: zuiji},
+	{title: "HbA1c(NGSP)", src: in("HbA1c(NGSP)"), conv: one(numChk)},
+	{title: "膵機能判定区分コード", src: in("膵機能判定"), conv: hanteiCd},
+	{title: "膵機能判定区分名称", src: in("膵機能判定"), conv: hanteiName},
+	{title: "血清アミラーゼ", src: in("血清アミラーゼ"), conv: one(numChk)},
+	{title: "　レベル区分"},
+	{title: "膵アミラーゼ"},
+	{title: "　レベル区分"},
+	{title: "尿酸", src: in("尿酸"), conv: one(numChk)},
+	{title: "尿素窒素", src: in("尿素窒素"), conv: one(numChk)},
+	{title: "血清クレアチニン", src: in("クレアチニン"), conv: one(numChk)},
+	{title: "eGFR", src: in("eGFR"), conv: one(numChk)},
+	{title: "[Met]血清クレアチニン対象", src: in("クレアチニン"), conv: taisyoConv},
+	{title: "[Met]血清クレアチニン実施理由"},
+	{title: "ナトリウム", src: in("ナトリウム"), conv: one(numChk)},
+	{title: "カリウム", src: in("カリウム"), conv: one(numChk)},
+	{title: "クロール", src: in("クロール"), conv: one(numChk)},
+	{title: "カルシウム", src: in("カルシウム"), conv: one(numChk)},
+	{title: "マグネシウム"},
+	{title: "無機リン", src: in("無機リン"), conv: one(numChk)},
+	{title: "カンマ位置(331)", conv: fixed("331")},
+	{title: "肝炎判定区分コード"},
+	{title: "肝炎判定区分名称"},
+	{title: "HBs抗原定性", src: in("HBs抗原定性"), conv: one(teiseiConv)},
+	{title: "HBs抗体定性", src: in("HBs抗体定性"), conv: one(teiseiConv)},
+	{title: "HCV抗体定性", src: in("HCV抗体定性"), conv: one(teiseiConv)},
+	{title: "HBs抗原定量", src: in("HBs抗原定量"), conv: one(numChk)},
+	{title: "　HBs抗原定量　陰・陽区分"},
+	{title: "HBs抗体定量", src: in("HBs抗体定量"), conv: one(numChk)},
+	{title: "　HBs抗体定量　陰・陽区分"},
+	{title: "HCV抗体定量", src: in("HCV抗体定量"), conv: one(numChk)},
+	{title: "　HCV抗体定量　陰・陽区分"},
+	{title: "CRP定性"},
+	{title: "CRP定量", src: in("CRP"), conv: one(numChk)},
+	{title: "　CRP定量　陰・陽区分"},
+	{title: "高感度CRP"},
+	{title: "　高感度CRP定量　陰・陽区分"},
+	{title: "RA(RF)定性"},
+	{title: "RF定量", src: in("RF定量"), conv: one(numChk)},
+	{title: "　RF定量　陰・陽区分"},
+	{title: "梅毒　総　陰・陽区分"},
+	{title: "梅毒反応(TPHA)　定性", src: in("TPHA定性"), conv: one(teiseiConv)},
+	{title: "梅毒反応(TPHA)　定量"},
+	{title: "　TPHA定量　陰・陽区分"},
+	{title: "梅毒反応(RPR)　定性", src: in("RPR定性"), conv: one(teiseiConv)},
+	{title: "梅毒反応(ガラス板)　定性"},
+	{title: "PSA定性"},
+	{title: "PSA定量", src: in("PSA"), conv: one(numChk)},
+	{title: "　PSA定量　陰・陽区分", src: in("PSA", "受診日", "性別", "年齢"), conv: marker("PSA")},
+	{title: "CA125", src: in("CA125"), conv: one(numChk)},
+	{title: "　CA125　陰・陽区分", src: in("CA125", "受診日", "性別", "年齢"), conv: marker("CA125")},
+	{title: "CA19_9", src: in("CA19-9"), conv: one(numChk)},
+	{title: "　CA19_9　陰・陽区分", src: in("CA19-9", "受診日", "性別", "年齢"), conv: marker("CA19-9")},
+	{title: "CEA", src: in("CEA"), conv: one(numChk)},
+	{title: "　CEA　陰・陽区分", src: in("CEA", "受診日", "性別", "年齢"), conv: marker("CEA")},
+	{title: "AFP", src: in("AFP"), conv: one(numChk)},
+	{title: "　AFP　陰・陽区分", src: in("AFP", "受診日", "性別", "年齢"), conv: marker("AFP")},
+	{title: "シフラ", src: in("シフラ"), conv: one(numChk)},
+	{title: "　シフラ　陰・陽区分", src: in("シフラ", "受診日", "性別", "年齢"), conv: marker("シフラ")},
+	{title: "TSH", src: in("TSH"), conv: one(numChk)},
+	{title: "　レベル区分"},
+	{title: "T3"},
+	{title: "　レベル区分"},
+	{title: "T4"},
+	{title: "　レベル区分"},
+	{title: "FT3", src: in("FT3"), conv: one(numChk)},
+	{title: "　レベル区分"},
+	{title: "FT4", src: in("FT4"), conv: one(numChk)},
+	{title: "　レベル区分"},
+	{title: "便中卵定性", src: in("便虫卵"), conv: one(teiseiConv)},
+	{title: "便中卵所見"},
+	{title: "カンマ位置(382)", conv: fixed("382")},
+	{title: "胃部X線実施区分"},
+	{title: "胃部X線未実施理由"},
+	{title: "胃部X線判定区分コード", src: in("胃部X線判定"), conv: hanteiCd},
+	{title: "胃部X線判定区分名称", src: in("胃部X線判定"), conv: hanteiName},
+	{title: "（予備）留意所見有無区分"},
+	{title: "胃部X線撮影区分", src: in("胃部X線間接", "胃部X線直接"), conv: satsueiConv},
+	{title: "胃部X線部位・所見（文字）", src: in("胃部X線所見1", "胃部X線所見2", "胃部X線所見3", "胃部X線所見4", "胃部X線所見5"), conv: join, limit: 240},
+	{title: "胃カメラ実施区分"},
+	{title: "胃カメラ未実施理由"},
+	{title: "胃カメラ判定区分コード", src: in("胃内視鏡判定"), conv: hanteiCd},
+	{title: "胃カメラ判定区分名称", src: in("胃内視鏡判定"), conv: hanteiName},
+	{title: "（予備）留意所見有無区分"},
+	{title: "胃部内視鏡部位・所見（文字）", src: in("胃内視鏡所見1", "胃内視鏡所見2", "胃内視鏡所見3", "胃内視鏡所見4", "胃内視鏡所見5"), conv: join, limit: 240},
+	{title: "胃部内視鏡組織検査実施区分"},
+	{title: "胃部内視鏡組織・生検所見", src: in("胃生検所見1", "胃生検所見2"), conv: join, limit: 240},
+	{title: "PG・ピロリ判定区分コード", src: in("ピロリ菌判定", "PG検査判定"), conv: heavyCd},
+	{title: "PG・ピロリ判定区分名称", src: in("ピロリ菌判定", "PG検査判定"), conv: heavyName},
+	{title: "ABC検診判定分類", src: in("胃ABC分類"), conv: one(iabcConv)},
+	{title: "PGⅠ", src: in("PGⅠ"), conv: one(numChk)},
+	{title: "PGⅡ", src: in("PGⅡ"), conv: one(numChk)},
+	{title: "PGⅠ/Ⅱ比", src: in("PGⅠ/Ⅱ比"), conv: one(numChk)},
+	{title: "PG比　陰・陽区分"},
+	{title: "ピロリIgG抗体定量", src: in("ピロリ抗体定量"), conv: one(numChk)},
+	{title: "ピロリIgG抗体定量　陰・陽区分", src: in("ピロリ抗体定性"), conv: one(teiseiConv)},
+	{title: "尿中ピロリ菌抗体定性"},
+	{title: "呼気ピロリ菌抗体定性"},
+	{title: "PGに関する所見"},
+	{title: "大腸内視鏡実施区分"},
+	{title: "大腸内視鏡未実施理由"},
+	{title: "大腸内視鏡判定区分コード"},
+	{title: "大腸内視鏡判定区分名称"},
+	{title: "（予備）留意所見有無区分"},
+	{title: "大腸内視鏡部位・所見（文字）"},
+	{title: "直腸診実施区分"},
+	{title: "直腸診未実施区分"},
+	{title: "直腸診判定区分コード"},
+	{title: "直腸診判定区分名称"},
+	{title: "（予備）留意所見有無区分"},
+	{title: "直腸診部位・所見（文字）"},
+	{title: "便潜血実施区分"},
+	{title: "便潜血未実施理由"},
+	{title: "便潜血判定区分コード", src: in("便判定"), conv: hanteiCd},
+	{title: "便潜血判定区分名称", src: in("便判定"), conv: hanteiName},
+	{title: "便潜血１回目（定性）", src: in("便潜血1回目"), conv: one(teiseiConv)},
+	{title: "便潜血２回目（定性）", src: in("便潜血2回目"), conv: one(teiseiConv)},
+	{title: "便潜血１回目定量"},
+	{title: "　１回目定量　陰・陽区分"},
+	{title: "便潜血２回目定量"},
+	{title: "　２回目定量　陰・陽区分"},
+	{title: "カンマ位置(432)", conv: fixed("432")},
+	{title: "乳がん総判定区分コード", src: in("乳腺超音波判定", "マンモグラフィー判定"), conv: heavyCd},
+	{title: "乳がん総判定区分名称", src: in("乳腺超音波判定", "マンモグラフィー判定"), conv: heavyName},
+	{title: "（予備）留意所見有無区分"},
+	{title: "乳がん総合所見（文字）"},
+	{title: "乳房視触診（文字）"},
+	{title: "乳腺エコー実施区分"},
+	{title: "乳腺エコー未実施理由"},
+	{title: "乳腺エコー判定区分コード", src: in("乳腺超音波判定"), conv: hanteiCd},
+	{title: "乳腺エコー判定区分名称", src: in("乳腺超音波判定"), conv: hanteiName},
+	{title: "（予備）留意所見有無区分"},
+	{title: "乳腺エコー所見（文字）", src: in("乳腺エコー所見1", "乳腺エコー所見2", "乳腺エコー所見3"), conv: join, limit: 240},
+	{title: "マンモ実施区分"},
+	{title: "マンモ未実施理由"},
+	{title: "マンモ判定区分コード", src: in("マンモグラフィー判定"), conv: hanteiCd},
+	{title: "マンモ判定区分名称", src: in("マンモグラフィー判定"), conv: hanteiName},
+	{title: "（予備）留意所見有無区分"},
+	{title: "マンモ撮影方向", src: in("マンモ1方向", "マンモ2方向"), conv: mmg},
+	{title: "マンモ所見（文字）", src: in("マンモ所見1", "マンモ所見2", "マンモ所見3"), conv: join, limit: 240},
+	{title: "子宮頸部細胞診実施区分"},
+	{title: "子宮頸部細胞診未実施区分"},
+	{title: "子宮頸部細胞診判定区分コード", src: in("婦人科内診判定", "子宮細胞診判定"), conv: heavyCd},
+	{title: "子宮頸部細胞診判定区分名称", src: in("婦人科内診判定", "子宮細胞診判定"), conv: heavyName},
+	{title: "（予備）留意所見有無区分"},
+	{title: "子宮内診所見（文字）", src: in("婦人科内診所見1", "婦人科内診所見2", "婦人科内診所見3"), conv: join, limit: 240},
+	{title: "子宮頸部細胞診（ベセスダ）", src: in("ベセスダ分類"), conv: one(vesesudaConv)},
+	{title: "子宮頸部細胞診（日母分類）", src: in("日母分類"), conv: one(nichimoConv)},
+	{title: "子宮頸部細胞診結果"},
+	{title: "HPV"},
+	{title: "子宮超音波実施区分"},
+	{title: "子宮超音波未実施理由"},
+	{title: "子宮超音波判定区分コード"},
+	{title: "子宮超音波判定区分名称"},
+	{title: "（予備）留意所見有無区分"},
+	{title: "子宮超音波所見（文字）"},
+	{title: "骨密度(BMD)", src: in("骨密度"), conv: one(numChk)},
+	{title: "YAM"},
+	{title: "同性年代平均値比"},
+	{title: "骨密度検査その他"},
+	{title: "心臓超音波実施区分"},
+	{title: "心臓超音波未実施理由"},
+	{title: "心臓超音波判定区分コード", src: in("心エコー判定"), conv: hanteiCd},
+	{title: "心臓超音波判定区分名称", src: in("心エコー判定"), conv: hanteiName},
+	{title: "心臓超音波所見（文字）", src: in("心エコー所見1", "心エコー所見2", "心エコー所見3", "心エコー所見4"), conv: join, limit: 240},
+	{title: "ABI 右", src: in("ABI右"), conv: one(numChk)},
+	{title: "ABI 左", src: in("ABI左"), conv: one(numChk)},
+	{title: "PWV 右"},
+	{title: "PWV 左"},
+	{title: "CAVI 右", src: in("CAVI右"), conv: one(numChk)},
+	{title: "CAVI 左", src: in("CAVI左"), conv: one(numChk)},
+	{title: "脳ドック実施区分"},
+	{title: "脳ドック検査種別"},
+	{title: "脳ドック総判定区分コード"},
+	{title: "脳ドック総判定区分名称"},
+	{title: "（予備）留意所見有無区分"},
+	{title: "脳ドック所見（文字）"},
+	{title: "頸動脈超音波実施区分"},
+	{title: "頸動脈超音波判定区分コード", src: in("頸動脈エコー判定"), conv: hanteiCd},
+	{title: "頸動脈超音波判定区分名称", src: in("頸動脈エコー判定"), conv: hanteiName},
+	{title: "（予備）留意所見有無区分"},
+	{title: "頸動脈超音波所見（文字）", src: in("頸動脈エコー所見1", "頸動脈エコー所見2", "頸動脈エコー所見3"), conv: join, limit: 240},
+	{title: "甲状腺超音波実施区分"},
+	{title: "甲状腺超音波判定区分コード", src: in("甲状腺エコー判定"), conv: hanteiCd},
+	{title: "甲状腺超音波判定区分名称", src: in("甲状腺エコー判定"), conv: hanteiName},
+	{title: "（予備）留意所見有無区分"},
+	{title: "甲状腺超音波部位所見（文字）", src: in("甲状腺エコー所見1", "甲状腺エコー所見2", "甲状腺エコー所見3", "甲状腺エコー所見4"), conv: join, limit: 240},
+	{title: "[Met]既往歴有無", src: metKiouSrc, conv: metKiouUmu},
+	{title: "[Met]具体的な既往歴", src: metKiouSrc, conv: metKiou, limit: 256},
+	{title: "[Met]自覚症状の有無", src: in("自覚症状1", "自覚症状2", "自覚症状3", "自覚症状4", "自覚症状5"), conv: jikaku},
+	{title: "[Met]具体的な自覚症状", src: in("自覚症状1", "自覚症状2", "自覚症状3", "自覚症状4", "自覚症状5"), conv: join, limit: 256},
+	{title: "[Met]他覚症状の有無", src: in("診察所見1", "診察所見2", "診察所見3"), conv: takaku},
+	{title: "[Met]具体的な他覚症状", src: in("診察所見1", "診察所見2", "診察所見3"), conv: join, limit: 256},
+	{title: "[Met]高血圧（服薬有無）", src: in("服薬1(血圧)"), conv: one(yesNoConv)},
+	{title: "[Met]高血圧（薬剤名）"},
+	{title: "[Met]高血圧（服薬理由）"},
+	{title: "[Met]糖尿病（服薬有無）", src: in("服薬2(血糖)"), conv: one(yesNoConv)},
+	{title: "[Met]糖尿病（薬剤名）"},
+	{title: "[Met]糖尿病（服薬理由）"},
+	{title: "[Met]脂質（服薬有無）", src: in("服薬3(脂質)"), conv: one(yesNoConv)},
+	{title: "[Met]脂質（薬剤名）"},
+	{title: "[Met]脂質（服薬理由）"},
+	{title: "[Met]既往歴１（脳血管有無）", src: in("既往歴1(脳血管)"), conv: one(yesNoConv)},
+	{title: "[Met]既往歴２（心血管有無）", src: in("既往歴2(心血管)"), conv: one(yesNoConv)},
+	{title: "[Met]既往歴３（腎不全・人口透析有無）", src: in("既往歴3(腎不全・人工透析)"), conv: one(yesNoConv)},
+	{title: "[Met]貧血既往有無", src: in("貧血"), conv: one(yesNoConv)},
+	{title: "[Met]習慣的喫煙", src: in("喫煙"), conv: one(yesNoConv)},
+	{title: "[Met]喫煙本数／日"},
+	{title: "[Met]喫煙期間（年）"},
+	{title: "[Met]20歳からの体重変化", src: in("20歳からの体重変化"), conv: one(yesNoConv)},
+	{title: "[Met]30分以上の運動習慣", src: in("30分以上の運動習慣"), conv: one(yesNoConv)},
+	{title: "[Met]歩行又は身体活動", src: in("歩行又は身体活動"), conv: one(yesNoConv)},
+	{title: "[Met]歩行速度", src: in("歩行速度"), conv: one(yesNoConv)},
+	{title: "[Met]咀嚼", src: in("咀嚼"), conv: one(sosyakuConv)},
+	{title: "[Met]食べ方１（早食い等）", src: in("食べ方1(早食い等)"), conv: one(eat1Conv)},
+	{title: "[Met]食べ方２（就寝前）", src: in("食べ方2(就寝前)"), conv: one(yesNoConv)},
+	{title: "[Met]食べ方３（間食）", src: in("食べ方3(間食)"), conv: one(eat3Conv)},
+	{title: "[Met]食習慣（朝食）", src: in("食習慣(朝食)"), conv: one(yesNoConv)},
+	{title: "[Met]飲酒習慣", src: in("飲酒"), conv: one(sakeConv)},
+	{title: "[Met]飲酒量", src: in("飲酒量"), conv: one(sakeryoConv)},
+	{title: "[Met]睡眠", src: in("睡眠"), conv: one(yesNoConv)},
+	{title: "[Met]生活習慣の改善意志", src: in("生活習慣の改善"), conv: one(seikatsuConv)},
+	{title: "[Met]保健指導の希望", src: in("保健指導の希望"), conv: one(yesNoConv)},
+	{title: "[Met]保健指導レベル", src: in("保健指導レベル"), conv: one(hokenConv)},
+	{title: "[Met]メタボリックシンドローム判定", src: in("メタボリックシンドローム判定"), conv: one(metaboConv)},
+	{title: "[Met]医師の診断（特定健診）", src: in("医師の診断")},
+	{title: "初回面接実施"},
+	{title: "初回面接補足内容"},
+	{title: "情報提供の方法"},
+	{title: "カンマ位置(540)", conv: fixed("540")},
+}
+
+func Title() []string {
+	// タイトル行を返す
+
+	title := make([]string, 0, len(columns))
+	for _, col := range columns {
+		title = append(title, col.title)
+	}
+
+	return title
+}
+
+func (col column) value(rec *record) string {
+	// 列定義にしたがって１列分の値を作成する
+
+	v := make([]string, len(col.src))
+	for i, name := range col.src {
+		v[i] = rec.get(name)
+	}
+	rec.col, rec.v = &col, v
+	defer func() { rec.col, rec.v = nil, nil }()
+
+	str := ""
+	if col.conv != nil {
+		s, err := col.conv(rec, v)
+		rec.check(err)
+		str = s
+	} else if len(v) > 0 {
+		str = v[0]
+	}
+	str = rec.cp932(str)
+
+	if rec.rule.required(col.req) {
+		rec.check(issue(CodeRequired, requireChk(v[0], col.req)))
+	}
+
+	// 最大バイト数を超えた分は切り捨てて、切り捨てた文字列を警告にする
+	if col.limit > 0 {
+		kept, lost := cutStr(str, col.limit)
+		if lost != "" {
+			rec.check(warn(CodeLength, fmt.Errorf("%dバイトを超えたため切り捨てました[%s]", col.limit, lost)))
+		}
+		str = kept
+	}
+
+	return str
+}
+
+func in(names ...string) []string {
+	// 抽出データの項目名を並べる
+
+	return names
+}
+
+func fixed(str string) convFunc {
+	// 固定値を返す
+
+	return func(rec *record, v []string) (string, error) {
+		return str, nil
+	}
+}
+
+func profile(key string) convFunc {
+	// 施設プロファイルの値を返す
+
+	return func(rec *record, v []string) (string, error) {
+		return rec.c.Profile.Get(key), nil
+	}
+}
+
+func one(f func(string) (string, error)) convFunc {
+	// 1つの列を変換する関数を列定義用にする
+
+	return func(rec *record, v []string) (string, error) {
+		return f(v[0])
+	}
+}
+
+func createdDay(rec *record, v []string) (string, error) {
+	// データ作成日を返す（指定が無ければ変換の基準日）
+
+	return rec.created, nil
+}
+
+func submitDay(rec *record, v []string) (string, error) {
+	// データ提出日を返す（指定が無ければ変換の基準日）
+
+	return rec.submit, nil
+}
+
+func kanryoKubun(rec *record, v []string) (string, error) {
+	// データ登録完了区分を返す（結果待ちの検査があれば未完了）
+
+	exams := rec.pendingExams()
+	if len(exams) == 0 {
+		return kubunDone, nil
+	}
+
+	return rec.c.Profile.Get("未完了データの登録完了区分"), warn(CodePending, fmt.Errorf("%sの結果待ちのため未完了で書き出します", strings.Join(exams, "・")))
+}
+
+func mikanryoMsg(rec *record, v []string) (string, error) {
+	// 登録未完了の連絡内容を返す
+
+	exams := rec.pendingExams()
+	if len(exams) == 0 {
+		return "", nil
+	}
+
+	return pendingMessage(exams), nil
+}
+
+func kojinId(rec *record, v []string) (string, error) {
+	// 個人IDを所属ルールの確認方法で確認する
+
+	if rec.rule == nil {
+		return v[0], nil
+	}
+
+	str, err := kojinIdChk(v[0], rec.rule.KojinId)
+	return str, issue(CodeKojinId, err)
+}
+
+func courseCd(rec *record, v []string) (string, error) {
+	// コースコードを返す
+
+	rec.checkAge()
+
+	cd, _, err := rec.c.Courses.Classify(v[0], v[1], rec.ages(), v[3])
+	return cd, issue(CodeCourse, err)
+}
+
+func courseName(rec *record, v []string) (string, error) {
+	// コース名称を返す（エラーはコースコードで記録する）
+
+	_, name, _ := rec.c.Courses.Classify(v[0], v[1], rec.ages(), v[3])
+	return name, nil
+}
+
+func hanteiCd(rec *record, v []string) (string, error) {
+	// 判定区分コードを返す
+
+	cd, _, err := hanteiCdConv(v[0])
+	return cd, err
+}
+
+func hanteiName(rec *record, v []string) (string, error) {
+	// 判定区分名称を返す（エラーは判定区分コードで記録する）
+
+	_, name, _ := hanteiCdConv(v[0])
+	return name, nil
+}
+
+func heavyCd(rec *record, v []string) (string, error) {
+	// 2つの判定のうち重い方の判定区分コードを返す
+
+	str, err := hantiHeavy(v[0], v[1])
+	rec.check(err)
+
+	cd, _, err := hanteiCdConv(str)
+	return cd, err
+}
+
+func heavyName(rec *record, v []string) (string, error) {
+	// 2つの判定のうち重い方の判定区分名称を返す
+
+	str, _ := hantiHeavy(v[0], v[1])
+	_, name, _ := hanteiCdConv(str)
+	return name, nil
+}
+
+func join(rec *record, v []string) (string, error) {
+	// 文字列を結合する
+
+	str := ""
+	for _, s := range v {
+		str = joinStr(str, s)
+	}
+
+	return str, nil
+}
+
+func joinTrim(rec *record, v []string) (string, error) {
+	// 前後の空白を削除して文字列を結合する
+
+	str := ""
+	for _, s := range v {
+		str = joinStr(str, strings.TrimSpace(s))
+	}
+
+	return str, nil
+}
+
+func kiouPairs(v []string) ([]string, []string) {
+	// 既往歴の列を病名と転帰に分ける
+
+	var kiou, tenki []string
+	for i := 0; i+1 < len(v); i += 2 {
+		kiou = append(kiou, v[i])
+		tenki = append(tenki, v[i+1])
+	}
+
+	return kiou, tenki
+}
+
+func chiryoUmu(rec *record, v []string) (string, error) {
+	// 治療中疾病有無区分を返す
+
+	flag, _ := tenkiConv(kiouPairs(v))
+	return flag, nil
+}
+
+func kiouUmu(rec *record, v []string) (string, error) {
+	// 既往疾病有無区分を返す
+
+	_, flag := tenkiConv(kiouPairs(v))
+	return flag, nil
+}
+
+func chiryoName(rec *record, v []string) (string, error) {
+	// 治療中疾病名を返す
+
+	name, _ := kiouConv(kiouPairs(v))
+	return name, nil
+}
+
+func kiouName(rec *record, v []string) (string, error) {
+	// 既往疾病名を返す
+
+	_, name := kiouConv(kiouPairs(v))
+	return name, nil
+}
+
+func sogo(rec *record, v []string) (string, error) {
+	// 総合判定コメントを返す
+
+	var sogoStr [][2]string
+	for i := 0; i+1 < len(v); i += 2 {
+		sogoStr = append(sogoStr, [2]string{v[i], v[i+1]})
+	}
+
+	return sogoConv(sogoStr)
+}
+
+func eyeValue(rec *record, v []string) (string, error) {
+	// 視力の値を返す
+
+	value, _ := eyeConv(v[0])
+	return value, nil
+}
+
+func eyeCode(rec *record, v []string) (string, error) {
+	// 視力のデータ属性を返す
+
+	_, code := eyeConv(v[0])
+	return code, nil
+}
+
+func eyeKubunConv(rec *record, v []string) (string, error) {
+	// 視力矯正区分を返す
+
+	return eyeKubun(v[0], v[1], v[2], v[3]), nil
+}
+
+func ear4k(rec *record, v []string) (string, error) {
+	// 聴力4000Hzの所見区分を返す
+
+	return ear4kHantei(v[0], v[1])
+}
+
+func earDB(rec *record, v []string) (string, error) {
+	// 聴力の値(dB)を返す
+
+	return earConv(strings.Join(v, "")), nil
+}
+
+func kaiwa(rec *record, v []string) (string, error) {
+	// 聴力会話法の所見区分を返す
+
+	return earKaiwa(v[0], v[1])
+}
+
+func ketsuatuH(rec *record, v []string) (string, error) {
+	// 報告値とする血圧(1回目か2回目)を返す
+
+	times, err := ketsuatuTimes(v[0], v[1], v[2], v[3])
+	if err != nil || times == 1 {
+		return v[4], err
+	}
+
+	return v[5], nil
+}
+
+func ketsuatuL(rec *record, v []string) (string, error) {
+	// 報告値とする血圧(1回目か2回目)を返す（エラーは収縮期血圧で記録する）
+
+	str, _ := ketsuatuH(rec, v)
+	return str, nil
+}
+
+func taisyoConv(rec *record, v []string) (string, error) {
+	// 実施対象を返す
+
+	return taisyo(v[0]), nil
+}
+
+func satsueiConv(rec *record, v []string) (string, error) {
+	// 撮影区分を返す
+
+	return satsuei(v[0], v[1]), nil
+}
+
+func ctHanteiCd(rec *record, v []string) (string, error) {
+	// 胸部CTの判定区分コードを返す（胸部CTがなければ空欄）
+
+	if v[0] == "" {
+		return "", nil
+	}
+
+	return hanteiCd(rec, v[1:])
+}
+
+func ctHanteiName(rec *record, v []string) (string, error) {
+	// 胸部CTの判定区分名称を返す（胸部CTがなければ空欄）
+
+	if v[0] == "" {
+		return "", nil
+	}
+
+	return hanteiName(rec, v[1:])
+}
+
+func ctSyoken(rec *record, v []string) (string, error) {
+	// 胸部CTの所見を返す（胸部CTがなければ空欄）
+
+	if v[0] == "" {
+		return "", nil
+	}
+
+	return joinTrim(rec, v[1:])
+}
+
+func kakutanCd(rec *record, v []string) (string, error) {
+	// 喀痰判定区分コードを返す
+
+	cd, _, _, err := kakutanConv(v[0])
+	return cd, err
+}
+
+func kakutanName(rec *record, v []string) (string, error) {
+	// 喀痰判定区分名称を返す（エラーは喀痰判定区分コードで記録する）
+
+	_, name, _, _ := kakutanConv(v[0])
+	return name, nil
+}
+
+func kakutanKekka(rec *record, v []string) (string, error) {
+	// 喀痰細胞診結果を返す（エラーは喀痰判定区分コードで記録する）
+
+	_, _, str, _ := kakutanConv(v[0])
+	return str, nil
+}
+
+func scheie(rec *record, v []string) (string, error) {
+	// シェイエ分類を返す
+
+	return scheieConv(v[0], v[1]), nil
+}
+
+func saikin(rec *record, v []string) (string, error) {
+	// 尿沈渣細菌を返す
+
+	str, _ := nyoChinsaConv(v[0], v[1], v[2])
+	return str, nil
+}
+
+func chinsaSonota(rec *record, v []string) (string, error) {
+	// 尿沈渣その他を返す
+
+	_, str := nyoChinsaConv(v[0], v[1], v[2])
+	return str, nil
+}
+
+func eatTime(rec *record, v []string) (string, error) {
+	// 食後時間区分を返す
+
+	return eatTimeConv(v[0], v[1])
+}
+
+func kufuku(rec *record, v []string) (string, error) {
+	// 空腹時血糖を返す
+
+	eatTime, _ := eatTimeConv(v[0], v[1])
+	str, _ := tohConv(v[0], eatTime)
+	return str, nil
+}
+
+func zuiji(rec *record, v []string) (string, error) {
+	// 随時血糖を返す
+
+	eatTime, _ := eatTimeConv(v[0], v[1])
+	_, str := tohConv(v[0], eatTime)
+	return str, nil
+}
+
+func ninshin(rec *record, v []string) (string, error) {
+	// 妊娠区分を返す
+
+	return ninshinConv(v[0], v[1])
+}
+
+func nyubi(rec *record, v []string) (string, error) {
+	// 乳びを返す
+
+	return nyubiConv(v[0], v[1]), nil
+}
+
+func yoketsu(rec *record, v []string) (string, error) {
+	// 溶血を返す
+
+	return yoketsuConv(v[0], v[1]), nil
+}
+
+func marker(test string) convFunc {
+	// 腫瘍マーカーの陰・陽区分を基準値マスタから返す（数値のエラーは定量値で記録する）
+
+	return func(rec *record, v []string) (string, error) {
+		str, _ := numChk(v[0])
+		return rec.c.Ranges.Classify(test, str, v[1], v[2], rec.ages().Exam)
+	}
+}
+
+func mmg(rec *record, v []string) (string, error) {
+	// マンモ撮影方向を返す
+
+	return mmgSatsuei(v[0], v[1]), nil
+}
+
+func metKiou(rec *record, v []string) (string, error) {
+	// [Met]具体的な既往歴を返す（病名、年齢、転帰の順に並んだ列）
+
+	str := ""
+	for i := 0; i+2 < len(v); i += 3 {
+		str = joinStr(str, kiouJoin(v[i], v[i+1], v[i+2]))
+	}
+
+	return str, nil
+}
+
+func metKiouUmu(rec *record, v []string) (string, error) {
+	// [Met]既往歴有無を返す
+
+	str, _ := metKiou(rec, v)
+	return umuConv(str), nil
+}
+
+func jikaku(rec *record, v []string) (string, error) {
+	// [Met]自覚症状の有無を返す
+
+	str, _ := join(rec, v)
+	return jikakuUmu(limitStr(str, 256)), nil
+}
+
+func takaku(rec *record, v []string) (string, error) {
+	// [Met]他覚症状の有無を返す
+
+	str, _ := join(rec, v)
+	return takakuUmu(limitStr(str, 256)), nil
+}
@@ -0,0 +1,1550 @@
+package ricohsanai
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/width"
+)
+
+func requireChk(str string, reqName string) error {
+	// 必須項目が空欄か確認する
+
+	if str == "" {
+		return fmt.Errorf("必須項目[%s]が空欄です。", reqName)
+	} else {
+		return nil
+	}
+
+}
+
+func kojinIdChk(kojinId string, method string) (string, error) {
+	// 個人IDの確認（method は所属ルールマスタの個人IDの確認方法）
+
+	if method == "なし" {
+		return kojinId, nil
+	}
+
+	if kojinId == "" {
+		return "", fmt.Errorf("個人IDに値がありません[%s]", kojinId)
+	}
+
+	prefix := strings.TrimPrefix(method, "先頭")
+	if prefix != method && !strings.HasPrefix(kojinId, prefix) {
+		return kojinId, fmt.Errorf("個人IDの先頭が[%s]ではありません。[%s]", prefix, kojinId)
+	}
+
+	return kojinId, nil
+}
+
+func seiConv(sei string) (string, error) {
+	// 性別を変換する
+
+	str := ""
+	flag := false
+	switch sei {
+	case "":
+		str = ""
+	case "男":
+		str = "1"
+	case "女":
+		str = "2"
+	default:
+		flag = true
+	}
+
+	if flag {
+		return sei, fmt.Errorf("性別変換エラー[%s]", sei)
+	} else {
+		return str, nil
+	}
+
+}
+
+func sisetsuConv(sisetsu string) (string, error) {
+	// 施設/巡回区分を変換する
+
+	str := ""
+	flag := false
+	switch sisetsu {
+	case "":
+		str = ""
+	case "所内":
+		str = "1"
+	case "巡回":
+		str = "2"
+	default:
+		flag = true
+	}
+
+	if flag {
+		return sisetsu, fmt.Errorf("施設/巡回変換エラー[%s]", sisetsu)
+	} else {
+		return str, nil
+	}
+}
+
+func hanteiCdConv(hanteiCd string) (string, string, error) {
+	// 判定区分コードを変換する
+
+	cd := ""
+	name := ""
+	flag := false
+	switch hanteiCd {
+	case "":
+		cd = ""
+		name = ""
+	case "Ａ":
+		cd = "1"
+		name = "異常なし"
+	case "Ｂ":
+		cd = "2"
+		name = "軽度異常"
+	case "Ｃ":
+		cd = "3"
+		name = "要経過観察"
+	case "Ｄ":
+		cd = "5"
+		name = "要医療（要精検・要治療）"
+	case "Ｅ":
+		cd = "5"
+		name = "要医療（要精検・要治療）"
+	case "Ｆ":
+		cd = "5"
+		name = "要医療（要精検・要治療）"
+	case "Ｇ":
+		cd = "7"
+		name = "治療中"
+	case "Ｈ":
+		cd = "9"
+		name = "判定不能または再検"
+	default:
+		flag = true
+	}
+
+	if flag {
+		return cd, name, fmt.Errorf("判定区分変換エラー[%s]", hanteiCd)
+	} else {
+		return cd, name, nil
+	}
+
+}
+
+func kanaConv(kana string) string {
+	// 半角カナを全角にする
+	str := kana
+
+	str = strings.Replace(str, "ｶﾞ", "ガ", -1)
+	str = strings.Replace(str, "ｷﾞ", "ギ", -1)
+	str = strings.Replace(str, "ｸﾞ", "グ", -1)
+	str = strings.Replace(str, "ｹﾞ", "ゲ", -1)
+	str = strings.Replace(str, "ｺﾞ", "ゴ", -1)
+
+	str = strings.Replace(str, "ｻﾞ", "ザ", -1)
+	str = strings.Replace(str, "ｼﾞ", "ジ", -1)
+	str = strings.Replace(str, "ｽﾞ", "ズ", -1)
+	str = strings.Replace(str, "ｾﾞ", "ゼ", -1)
+	str = strings.Replace(str, "ｿﾞ", "ゾ", -1)
+
+	str = strings.Replace(str, "ﾀﾞ", "ダ", -1)
+	str = strings.Replace(str, "ﾁﾞ", "ヂ", -1)
+	str = strings.Replace(str, "ﾂﾞ", "ヅ", -1)
+	str = strings.Replace(str, "ﾃﾞ", "デ", -1)
+	str = strings.Replace(str, "ﾄﾞ", "ド", -1)
+
+	str = strings.Replace(str, "ﾊﾞ", "バ", -1)
+	str = strings.Replace(str, "ﾋﾞ", "ビ", -1)
+	str = strings.Replace(str, "ﾌﾞ", "ブ", -1)
+	str = strings.Replace(str, "ﾍﾞ", "ベ", -1)
+	str = strings.Replace(str, "ﾎﾞ", "ボ", -1)
+
+	str = strings.Replace(str, "ﾊﾟ", "パ", -1)
+	str = strings.Replace(str, "ﾋﾟ", "ピ", -1)
+	str = strings.Replace(str, "ﾌﾟ", "プ", -1)
+	str = strings.Replace(str, "ﾍﾟ", "ペ", -1)
+	str = strings.Replace(str, "ﾎﾟ", "ポ", -1)
+
+	str = strings.Replace(str, "ｳﾞ", "ヴ", -1)
+
+	str = strings.Replace(str, "ｱ", "ア", -1)
+	str = strings.Replace(str, "ｲ", "イ", -1)
+	str = strings.Replace(str, "ｳ", "ウ", -1)
+	str = strings.Replace(str, "ｴ", "エ", -1)
+	str = strings.Replace(str, "ｵ", "オ", -1)
+
+	str = strings.Replace(str, "ｧ", "ァ", -1)
+	str = strings.Replace(str, "ｨ", "ィ", -1)
+	str = strings.Replace(str, "ｩ", "ゥ", -1)
+	str = strings.Replace(str, "ｴ", "ェ", -1)
+	str = strings.Replace(str, "ｵ", "ォ", -1)
+
+	str = strings.Replace(str, "ｶ", "カ", -1)
+	str = strings.Replace(str, "ｷ", "キ", -1)
+	str = strings.Replace(str, "ｸ", "ク", -1)
+	str = strings.Replace(str, "ｹ", "ケ", -1)
+	str = strings.Replace(str, "ｺ", "コ", -1)
+
+	str = strings.Replace(str, "ｻ", "サ", -1)
+	str = strings.Replace(str, "ｼ", "シ", -1)
+	str = strings.Replace(str, "ｽ", "ス", -1)
+	str = strings.Replace(str, "ｾ", "セ", -1)
+	str = strings.Replace(str, "ｿ", "ソ", -1)
+
+	str = strings.Replace(str, "ﾀ", "タ", -1)
+	str = strings.Replace(str, "ﾁ", "チ", -1)
+	str = strings.Replace(str, "ﾂ", "ツ", -1)
+	str = strings.Replace(str, "ﾃ", "テ", -1)
+	str = strings.Replace(str, "ﾄ", "ト", -1)
+
+	str = strings.Replace(str, "ﾅ", "ナ", -1)
+	str = strings.Replace(str, "ﾆ", "ニ", -1)
+	str = strings.Replace(str, "ﾇ", "ヌ", -1)
+	str = strings.Replace(str, "ﾈ", "ネ", -1)
+	str = strings.Replace(str, "ﾉ", "ノ", -1)
+
+	str = strings.Replace(str, "ﾊ", "ハ", -1)
+	str = strings.Replace(str, "ﾋ", "ヒ", -1)
+	str = strings.Replace(str, "ﾌ", "フ", -1)
+	str = strings.Replace(str, "ﾍ", "ヘ", -1)
+	str = strings.Replace(str, "ﾎ", "ホ", -1)
+
+	str = strings.Replace(str, "ﾏ", "マ", -1)
+	str = strings.Replace(str, "ﾐ", "ミ", -1)
+	str = strings.Replace(str, "ﾑ", "ム", -1)
+	str = strings.Replace(str, "ﾒ", "メ", -1)
+	str = strings.Replace(str, "ﾓ", "モ", -1)
+
+	str = strings.Replace(str, "ﾔ", "ヤ", -1)
+	str = strings.Replace(str, "ﾕ", "ユ", -1)
+	str = strings.Replace(str, "ﾖ", "ヨ", -1)
+
+	str = strings.Replace(str, "ｬ", "ャ", -1)
+	str = strings.Replace(str, "ｭ", "ュ", -1)
+	str = strings.Replace(str, "ｮ", "ョ", -1)
+
+	str = strings.Replace(str, "ﾗ", "ラ", -1)
+	str = strings.Replace(str, "ﾘ", "リ", -1)
+	str = strings.Replace(str, "ﾙ", "ル", -1)
+	str = strings.Replace(str, "ﾚ", "レ", -1)
+	str = strings.Replace(str, "ﾛ", "ロ", -1)
+
+	str = strings.Replace(str, "ﾜ", "ワ", -1)
+	str = strings.Replace(str, "ｦ", "ヲ", -1)
+	str = strings.Replace(str, "ﾝ", "ン", -1)
+
+	str = strings.Replace(str, "ｰ", "ー", -1)
+
+	return str
+
+}
+
+func limitStr(str string, limit int) string {
+	// 文字列の最大をlimitで指定されたバイト数(shifJISのバイト数)で返す
+
+	kept, _ := cutStr(str, limit)
+	return kept
+}
+
+func cutStr(str string, limit int) (string, string) {
+	// 文字列をlimitで指定されたバイト数(CP932のバイト数)で切り、残した文字列と切り捨てた文字列を返す
+	// 半角カタカナは全角カタカナにしてから数える
+	// 文字の途中では切らず、後半に区切り(空白・読点・句点など)があればそこで切る
+
+	str = kanaConv(str) // 半角カタカナ -> カタカナを全角
+
+	n := 0    // 数えたバイト数
+	end := -1 // limit以内で切れる位置
+	sep := -1 // limit以内の最後の区切りの位置
+	for i, r := range str {
+		n += cp932Len(r)
+		if n > limit {
+			end = i
+			break
+		}
+		if strings.ContainsRune(" 　、。，．,/／・\n", r) {
+			sep = i + utf8.RuneLen(r)
+		}
+	}
+	if end < 0 {
+		return str, ""
+	}
+
+	// 区切りで切ると半分以上残らない時は文字の境目で切る
+	if sep > 0 && cp932Bytes(str[:sep]) >= limit/2 {
+		end = sep
+	}
+
+	kept := strings.TrimRight(str[:end], " 　")
+	lost := strings.TrimLeft(str[end:], " 　")
+
+	return kept, lost
+}
+
+func joinStr(str1 string, str2 string) string {
+	// 2つの文字列を結合する
+
+	str := ""
+
+	if str1 == "" {
+		str = str2
+	} else {
+		if str2 == "" {
+			str = str1
+		} else {
+			str = str1 + " " + str2
+		}
+	}
+
+	return str
+}
+
+func joinStr3(str1 string, str2 string, str3 string) string {
+	// 3つの文字列を結合する
+
+	str := ""
+	str = joinStr(str1, str2)
+	str = joinStr(str, str3)
+
+	return str
+
+}
+
+func chiryoChk(tenki string) bool {
+	// 治療中の項目があればTrueを返す
+
+	flag := false
+	if tenki == "内服治療中" || tenki == "管理中" {
+		flag = true
+	}
+
+	return flag
+
+}
+
+func tenkiConv(kiou []string, tenki []string) (string, string) {
+	// 既往歴を確認。治療中と既往があればそれぞれ"1"を返す
+
+	chiryoStr := ""
+	kiouStr := ""
+	for i, v := range kiou {
+		if v != "" {
+			if chiryoChk(tenki[i]) {
+				chiryoStr = "1"
+			} else {
+				kiouStr = "1"
+			}
+		}
+
+		if chiryoStr == "1" && kiouStr == "1" {
+			break
+		}
+	}
+
+	return chiryoStr, kiouStr
+
+}
+
+func kiouConv(kiou []string, tenki []string) (string, string) {
+	//既往歴から、治療中の病名と既往の病名に分けて返す
+
+	chiryoStr := ""
+	kiouStr := ""
+	for i, v := range kiou {
+		if v != "" {
+			if chiryoChk(tenki[i]) {
+				chiryoStr = joinStr(chiryoStr, v)
+			} else {
+				kiouStr = joinStr(kiouStr, v)
+			}
+		}
+	}
+
+	return chiryoStr, kiouStr
+}
+
+func kiouJoin(kiou string, age string, tenki string) string {
+	// 病名、年齢、転帰をつなげて返す
+
+	str := ""
+	if kiou == "" {
+		return ""
+	} else {
+		str = kiou
+	}
+
+	if age != "" {
+		str = str + " " + age + "才"
+	}
+
+	if tenki != "" {
+		str = str + " " + tenki
+	}
+
+	return str
+}
+
+func umuConv(str string) string {
+	// 値をみて有無(1:特記すべきことあり 2:特記すべきことなし)を返す
+
+	if str != "" {
+		return "1"
+	} else {
+		return "2"
+	}
+}
+
+func eyeConv(eye string) (string, string) {
+	// 視力の値とデータ属性(1:未満)を返す
+
+	value := eye
+	code := ""
+	if strings.Index(eye, "↓") != -1 {
+		value = strings.Replace(eye, "↓", "", -1)
+		code = "1"
+	}
+
+	return value, code
+}
+
+func eyeKubun(kyoseiR string, kyoseiL string, kintenR string, kintenL string) string {
+	// 視力矯正区分（1:眼鏡 2:CL 3:不明）を返す
+
+	if kyoseiR == "" && kyoseiL == "" && kintenR == "" && kintenL == "" {
+		return ""
+	} else {
+		return "3"
+	}
+}
+
+func yusyokenChk(hantei string) (bool, error) {
+	flag := false
+	errFlag := false
+	switch hantei {
+	case "C", "D", "E", "F", "G", "Ｃ", "Ｄ", "Ｅ", "Ｆ", "Ｇ":
+		flag = true
+	case "", "A", "B", "Ａ", "Ｂ":
+		flag = false
+	default:
+		errFlag = true
+	}
+
+	if errFlag {
+		return flag, fmt.Errorf("有所見判定変換エラー[%s]", hantei)
+	} else {
+		return flag, nil
+	}
+}
+
+func yusyokenKubun(flag bool) string {
+	// 有所見チェック yusyokenChk() の戻り値から、有所見区分(1:所見なし 2:所見あり)を返す
+
+	if flag {
+		return "2"
+	} else {
+		return "1"
+	}
+
+}
+
+func ear1kHantei(hantei string) (string, error) {
+	// 聴力1000Hzの所見区分(1:所見なし 2:所見あり)を返す
+
+	if hantei == "" {
+		return "", nil
+	}
+
+	if flag, err := yusyokenChk(hantei); err != nil {
+		return hantei, fmt.Errorf("聴力1000Hz判定変換エラー[%s]", hantei)
+	} else {
+		return yusyokenKubun(flag), nil
+	}
+
+}
+
+func ear4kHantei(hantei1 string, hantei2 string) (string, error) {
+	// 聴力4000Hzの所見区分(1:所見なし 2:所見あり)を返す
+
+	if hantei1 != "" {
+		if flag, err := yusyokenChk(hantei1); err != nil {
+			return hantei1, fmt.Errorf("聴力4000Hz判定変換エラー[%s]", hantei1)
+		} else {
+			return yusyokenKubun(flag), nil
+		}
+	}
+
+	if hantei2 != "" {
+		if flag, err := yusyokenChk(hantei2); err != nil {
+			return hantei2, fmt.Errorf("聴力4000Hz判定変換エラー[%s]", hantei2)
+		} else {
+			return yusyokenKubun(flag), nil
+		}
+	}
+
+	return "", nil
+
+}
+
+func earKaiwa(syoken string, hantei string) (string, error) {
+	// 聴力会話法の所見区分(1:所見なし 2:所見あり)を返す
+
+	if syoken == "" {
+		return "", nil
+	}
+
+	if flag, err := yusyokenChk(hantei); err != nil {
+		return hantei, fmt.Errorf("聴力会話法判定変換エラー[%s]", hantei)
+	} else {
+		return yusyokenKubun(flag), nil
+	}
+
+}
+
+func earConv(ear string) string {
+	// 聴力の値が * なら空欄にする
+
+	if ear == "*" || ear == "＊" {
+		return ""
+	} else {
+		return ear
+	}
+
+}
+
+func hanteiRank(hantei string) (int, error) {
+	// ABC判定の重さ（ランキング）を返す
+	rank := 0
+	flag := false
+	switch hantei {
+	case "":
+		rank = 0
+	case "A", "Ａ":
+		rank = 1
+	case "B", "Ｂ":
+		rank = 2
+	case "C", "Ｃ":
+		rank = 3
+	case "D", "Ｄ":
+		rank = 5
+	case "E", "Ｅ":
+		rank = 6
+	case "F", "Ｆ":
+		rank = 7
+	case "G", "Ｇ":
+		rank = 4
+	default:
+		flag = true
+	}
+
+	if flag {
+		return rank, fmt.Errorf("判定ランク変換エラー[%s]", hantei)
+	} else {
+		return rank, nil
+	}
+
+}
+
+func hantiHeavy(hantei1 string, hantei2 string) (string, error) {
+	// 重い方の判定を返す
+
+	var err error
+	rank1 := 0
+	rank2 := 0
+
+	rank1, err = hanteiRank(hantei1)
+	if err != nil {
+		return hantei1, err
+	}
+
+	rank2, err = hanteiRank(hantei2)
+	if err != nil {
+		return hantei2, err
+	}
+
+	if rank1 < rank2 {
+		return hantei2, nil
+	} else {
+		return hantei1, nil
+	}
+
+}
+
+func ketsuatuTimes(hantei1H string, hantei1L string, hantei2H string, hantei2L string) (int, error) {
+	// 血圧1回目と血圧2回目をどちらを報告値とするか決める(1:1回目, 2:2回目)
+
+	if hantei2H == "" && hantei2L == "" {
+		return 1, nil
+	}
+
+	rank1H, err := hanteiRank(hantei1H)
+	if err != nil {
+		return 1, err
+	}
+
+	rank1L, err := hanteiRank(hantei1L)
+	if err != nil {
+		return 1, err
+	}
+
+	rank2H, err := hanteiRank(hantei2H)
+	if err != nil {
+		return 1, err
+	}
+
+	rank2L, err := hanteiRank(hantei2L)
+	if err != nil {
+		return 1, err
+	}
+
+	count1 := 0
+	count2 := 0
+
+	if rank1H != rank2H {
+		if rank1H > rank2H {
+			count1++
+		} else {
+			count2++
+		}
+	}
+
+	if rank1L != rank2L {
+		if rank1L > rank2L {
+			count1++
+		} else {
+			count2++
+		}
+	}
+
+	if count1 >= count2 {
+		return 2, nil
+	} else {
+		return 1, nil
+	}
+
+}
+
+func syokenUmu(hantei string) (string, error) {
+	// 所見有無(1:異常所見あり 2:所見なし)を返す
+
+	umu := ""
+	flag := false
+	switch hantei {
+	case "":
+		umu = ""
+	case "A", "Ａ":
+		umu = "2"
+	case "B", "Ｂ":
+		umu = "2"
+	case "C", "Ｃ":
+		umu = "1"
+	case "D", "Ｄ":
+		umu = "1"
+	case "E", "Ｅ":
+		umu = "1"
+	case "F", "Ｆ":
+		umu = "1"
+	case "G", "Ｇ":
+		umu = "1"
+	default:
+		flag = true
+	}
+
+	if flag {
+		return "", fmt.Errorf("所見有無変換エラー[%s]", hantei)
+	} else {
+		return umu, nil
+	}
+
+}
+
+func taisyo(hantei string) string {
+	// 実施対象(hanteiに値がある)なら"0"を返す
+
+	if hantei == "" {
+		return ""
+	} else {
+		return "0"
+	}
+
+}
+
+func satsuei(kan string, cyoku string) string {
+	// 撮影方法(1:直接 2:間接 3:デジタル)を返す
+
+	if kan == "" && cyoku == "" {
+		return ""
+	}
+
+	if cyoku != "" {
+		return "3"
+	}
+
+	if kan != "" {
+		return "2"
+	}
+
+	return ""
+
+}
+
+func kakutanConv(kakutan string) (string, string, string, error) {
+	// 喀痰細胞診結果(1:A取り直し 2:B異常なし 3:C要注意 4:Dがんの疑い 5:Fがん)を返す
+
+	cd := ""
+	name := ""
+	str := ""
+	flag := false
+	switch kakutan {
+	case "":
+		cd = ""
+		name = ""
+		str = ""
+	case "Ⅰ":
+		cd = "1"
+		name = "異常なし"
+		str = "2"
+	case "Ⅱ":
+		cd = "2"
+		name = "軽度異常"
+		str = "2"
+	case "Ⅲ":
+		cd = "5"
+		name = "要医療（要精検・要治療）"
+		str = "3"
+	case "Ⅳ":
+		cd = "5"
+		name = "要医療（要精検・要治療）"
+		str = "4"
+	case "Ⅴ":
+		cd = "5"
+		name = "要医療（要精検・要治療）"
+		str = "5"
+	case "判定不能":
+		cd = "9"
+		name = "判定不能または再検"
+		str = "1"
+	default:
+		flag = true
+	}
+
+	if flag {
+		return "", "", "", fmt.Errorf("喀痰変換エラー[%s]", kakutan)
+	} else {
+		return cd, name, str, nil
+	}
+
+}
+
+func scheieConv(S string, H string) string {
+	// シェイエ分類を返す
+
+	if S == "" && H == "" {
+		return ""
+	}
+
+	return "S:" + width.Narrow.String(S) + "/H:" + width.Narrow.String(H)
+}
+
+func scottConv(scott string) (string, error) {
+	// scott分類を変換する
+
+	str := ""
+	flag := false
+	switch scott {
+	case "":
+		str = ""
+	case "０":
+		str = ""
+	case "Ⅰ":
+		str = "Ⅰ(a)"
+	case "Ⅰａ":
+		str = "Ⅰ(a)"
+	case "Ⅰｂ":
+		str = "Ⅰ(b)"
+	case "Ⅱ":
+		str = "Ⅱ"
+	case "Ⅲ":
+		str = "Ⅲ(a)"
+	case "Ⅲａ":
+		str = "Ⅲ(a)"
+	case "Ⅲｂ":
+		str = "Ⅲ(b)"
+	case "Ⅳ":
+		str = "Ⅳ"
+	case "Ⅴ":
+		str = "Ⅴ(a)"
+	case "Ⅴａ":
+		str = "Ⅴ(a)"
+	case "Ⅴｂ":
+		str = "Ⅴ(b)"
+	case "Ⅵ":
+		str = "Ⅵ"
+	default:
+		flag = true
+	}
+
+	if flag {
+		return "", fmt.Errorf("Scott分類変換エラー[%s]", scott)
+	} else {
+		return str, nil
+	}
+
+}
+
+func kwConv(kw string) (string, error) {
+	// KW分類を変換する
+
+	str := ""
+	flag := false
+	switch kw {
+	case "":
+		str = ""
+	case "０":
+		str = "０"
+	case "Ⅰ":
+		str = "Ⅰ"
+	case "Ⅱ":
+		str = "Ⅱ"
+	case "Ⅱａ":
+		str = "Ⅱ(a)"
+	case "Ⅱｂ":
+		str = "Ⅱ(b)"
+	case "Ⅲ":
+		str = "Ⅲ"
+	case "Ⅳ":
+		str = "Ⅳ"
+	default:
+		flag = true
+	}
+
+	if flag {
+		return "", fmt.Errorf("KW分類変換エラー[%s]", kw)
+	} else {
+		return str, nil
+	}
+
+}
+
+func teiseiConv(teisei string) (string, error) {
+	// 定性(1:(-), 2:(+-), 3:(+), 4:(2+), 5:(3+), 6:(4+), 7:(5+) )を変換する
+
+	str := ""
+	flag := false
+	switch teisei {
+	case "":
+		str = ""
+	case "-", "－":
+		str = "1"
+	case "+-":
+		str = "2"
+	case "+", "＋":
+		str = "3"
+	case "2+":
+		str = "4"
+	case "3+":
+		str = "5"
+	case "4+":
+		str = "6"
+	case "5+":
+		str = "7"
+	default:
+		flag = true
+	}
+
+	if flag {
+		return "", fmt.Errorf("定性変換エラー[%s]", teisei)
+	} else {
+		return str, nil
+	}
+
+}
+
+func nyoChinsaConv(sonota1 string, sonota2 string, sonota3 string) (string, string) {
+	// 尿沈渣その他１・２・３　より　細菌を分けて返す
+
+	if sonota1 == "" {
+		return "", ""
+	}
+
+	saikin := ""
+	if saikinChk(sonota1) {
+		saikin = "細菌"
+		sonota1 = ""
+	} else if saikinChk(sonota2) {
+		saikin = "細菌"
+		sonota2 = ""
+	} else if saikinChk(sonota3) {
+		saikin = "細菌"
+		sonota3 = ""
+	}
+
+	return saikin, joinStr3(sonota1, sonota2, sonota3)
+
+}
+
+func saikinChk(str string) bool {
+	// 細菌なら trueを返す
+
+	switch str {
+	case "細菌", "ｻｲｷﾝ":
+		return true
+	default:
+		return false
+	}
+
+}
+
+func aboConv(abo string) (string, error) {
+	// 血液型ABO(1:A 2:B 3:AB 4:O)を変換する
+
+	str := ""
+	flag := false
+	switch abo {
+	case "":
+		str = ""
+	case "Ａ型":
+		str = "1"
+	case "Ｂ型":
+		str = "2"
+	case "ＡＢ型":
+		str = "3"
+	case "Ｏ型":
+		str = "4"
+	default:
+		flag = true
+	}
+
+	if flag {
+		return "", fmt.Errorf("血液型ABO変換エラー[%s]", abo)
+	} else {
+		return str, nil
+	}
+
+}
+
+func rhConv(rh string) (string, error) {
+	// 血液型Rh(1:+ 2:-)を変換する
+
+	str := ""
+	flag := false
+	switch rh {
+	case "":
+		str = ""
+	case "（＋）":
+		str = "1"
+	case "（－）":
+		str = "2"
+	default:
+		flag = true
+	}
+
+	if flag {
+		return "", fmt.Errorf("血液型Rh変換エラー[%s]", rh)
+	} else {
+		return str, nil
+	}
+
+}
+
+func numChk(str string) (string, error) {
+	// 数値に変換できるか確認し、余計な文字列を削除する
+
+	if str == "" {
+		return "", nil
+	}
+
+	_, errInt := strconv.Atoi(str)
+	_, errFloat := strconv.ParseFloat(str, 64)
+
+	if errInt != nil && errFloat != nil {
+		switch true {
+		case strings.Index(str, "未満") >= 0:
+			return strings.Replace(str, "未満", "", -1), nil
+		case strings.Index(str, "以上") >= 0:
+			return strings.Replace(str, "以上", "", -1), nil
+		case strings.Index(str, "以下") >= 0:
+			return strings.Replace(str, "以下", "", -1), nil
+		default:
+			return str, fmt.Errorf("値に文字が含まれています。[%s]", str)
+		}
+	} else {
+		return str, nil
+	}
+
+}
+
+func eatTimeConv(toh string, eatTime string) (string, error) {
+	// 食後時間区分(2:10時間以上 3:食後3.5時間以上10時間未満 4: 食後3.5時間未満
+	// 空腹時血糖結果値がある場合は「2」を登録
+
+	if eatTime == "" && toh == "" {
+		return "", nil
+	}
+
+	if eatTime == "" && toh != "" {
+		return "2", nil //食後時間が空欄で血糖値があるなら 2:10時間以上
+	}
+
+	eatTimeFloat, err := strconv.ParseFloat(eatTime, 64)
+	if err == nil {
+		switch {
+		case eatTimeFloat < 3.5:
+			return "4", nil
+		case eatTimeFloat >= 3.5 && eatTimeFloat < 10.0:
+			return "3", nil
+		case eatTimeFloat >= 10.0:
+			return "2", nil
+		default:
+			return "", fmt.Errorf("食後時間の値が不正です。[%s]", eatTime)
+		}
+	} else {
+		return eatTime, err
+	}
+
+}
+
+func seiriConv(seiri string) (string, error) {
+	// 生理区分(1: 生理中)を返す
+
+	str := ""
+	flag := false
+	switch seiri {
+	case "":
+		str = ""
+	case "はい":
+		str = "1"
+	case "いいえ":
+		str = ""
+	default:
+		flag = true
+	}
+
+	if flag {
+		return "", fmt.Errorf("生理区分変換エラー[%s]", seiri)
+	} else {
+		return str, nil
+	}
+
+}
+
+func ninshinConv(ninshin string, utagai string) (string, error) {
+	// 妊娠区分(1: 妊娠中)を返す
+
+	if ninshin == "" && utagai == "" {
+		return "", nil
+	}
+
+	if ninshin == "はい" || utagai == "はい" {
+		return "1", nil
+	}
+
+	if ninshin == "いいえ" || utagai == "いいえ" {
+		return "", nil
+	}
+
+	if ninshin != "" {
+		return "", fmt.Errorf("妊娠区分変換エラー[%s]", ninshin)
+	}
+
+	if utagai != "" {
+		return "", fmt.Errorf("妊娠区分変換エラー[%s]", utagai)
+	}
+
+	return "", fmt.Errorf("妊娠区分変換エラー[%s][%s]", ninshin, utagai)
+}
+
+func nyubiConv(cmt1 string, cmt2 string) string {
+	// 乳び(1:1+ 2:2+ 3:3+)を返す
+
+	if cmt1 == "乳び血清" || cmt2 == "乳び血清" {
+		return "1"
+	}
+
+	if cmt1 == "乳糜検体" || cmt2 == "乳糜検体" {
+		return "1"
+	}
+
+	return ""
+
+}
+
+func yoketsuConv(cmt1 string, cmt2 string) string {
+	// 溶血(1:1+ 2:2+ 3:3+)を返す
+
+	if cmt1 == "強溶血血清" || cmt2 == "強溶血血清" {
+		return "2"
+	}
+
+	if cmt1 == "溶血血清" || cmt2 == "溶血血清" {
+		return "1"
+	}
+
+	if cmt1 == "溶血検体" || cmt2 == "溶血溶血" {
+		return "1"
+	}
+
+	return ""
+
+}
+
+func tohConv(toh string, eatTime string) (string, string) {
+	// 空腹時血糖と随時血糖を返す
+	// 空腹時血糖は食後10時間以上であること
+
+	if toh == "" {
+		return "", ""
+	}
+
+	switch eatTime {
+	case "2": // 10時間以上
+		return toh, ""
+	case "3": // 食後3.5時間以上10時間未満
+		return "", toh
+	case "4": // 食後3.5時間未満
+		return "", toh // 随時血糖ではないが一応値を返す
+	default:
+		return toh, "" // 空腹時血糖ではないが一応値を返す
+	}
+
+}
+
+func iabcConv(iabc string) (string, error) {
+	// 胃ABC検診判定分類を(1:A群 2:B群 3:C群 4:D群 5:判定不能 6:E群)返す
+
+	str := ""
+	flag := false
+	switch iabc {
+	case "":
+		str = ""
+	case "A群":
+		str = "1"
+	case "B群":
+		str = "2"
+	case "C群":
+		str = "3"
+	case "D群":
+		str = "4"
+	case "E群":
+		str = "6"
+	default:
+		flag = true
+	}
+
+	if flag {
+		return "", fmt.Errorf("胃ABC分類変換エラー[%s]", iabc)
+	} else {
+		return str, nil
+	}
+
+}
+
+func mmgSatsuei(one string, two string) string {
+	// マンモ撮影方法(1:1方向 2:2方向)を返す
+
+	if one == "" && two == "" {
+		return ""
+	}
+
+	if one != "" {
+		return "1"
+	}
+
+	if two != "" {
+		return "2"
+	}
+
+	return ""
+
+}
+
+func vesesudaConv(vesesuda string) (string, error) {
+	// ベセスダ分類を変換する
+
+	str := ""
+	flag := false
+	switch vesesuda {
+	case "":
+		str = ""
+	case "NILM":
+		str = "1"
+	case "ASC-US":
+		str = "2"
+	case "ASC-H":
+		str = "3"
+	case "LSIL":
+		str = "4"
+	case "HSIL":
+		str = "5"
+	case "SCC":
+		str = "6"
+	case "AGC":
+		str = "7"
+	case "AIS":
+		str = "8"
+	case "Adeno.ca":
+		str = "9"
+	case "Other":
+		str = "10"
+	default:
+		flag = true
+	}
+
+	if flag {
+		return "", fmt.Errorf("ベセスダ分類変換エラー[%s]", vesesuda)
+	} else {
+		return str, nil
+	}
+
+}
+
+func nichimoConv(nichimo string) (string, error) {
+	// 日母分類を変換する
+
+	str := ""
+	flag := false
+	switch nichimo {
+	case "":
+		str = ""
+	case "Ⅰ":
+		str = "1"
+	case "Ⅱ":
+		str = "2"
+	case "Ⅲａ":
+		str = "3"
+	case "Ⅲｂ":
+		str = "4"
+	case "Ⅳ":
+		str = "5"
+	case "Ⅴ":
+		str = "6"
+	default:
+		flag = true
+	}
+
+	if flag {
+		return "", fmt.Errorf("日母クラス分類変換エラー[%s]", nichimo)
+	} else {
+		return str, nil
+	}
+
+}
+
+func jikakuUmu(jikaku string) string {
+	// 自覚症状有無(1:特記すべきことあり 2: 特記すべきことなし)を返す
+
+	if jikaku == "" || jikaku == "特になし" {
+		return "2"
+	} else {
+		return "1"
+	}
+
+}
+
+func takakuUmu(takaku string) string {
+	// 他覚症状有無(1:特記すべきことあり 2: 特記すべきことなし)を返す
+
+	if takaku == "" || takaku == "異常なし" {
+		return "2"
+	} else {
+		return "1"
+	}
+
+}
+
+func yesNoConv(yesNo string) (string, error) {
+	// はい・いいえ(1:はい 2:いいえ)を変換する
+
+	str := ""
+	flag := false
+	switch yesNo {
+	case "":
+		str = ""
+	case "はい":
+		str = "1"
+	case "いいえ":
+		str = "2"
+	default:
+		flag = true
+	}
+
+	if flag {
+		return "", fmt.Errorf("はい・いいえ変換エラー[%s]", yesNo)
+	} else {
+		return str, nil
+	}
+
+}
+
+func sosyakuConv(sosyaku string) (string, error) {
+	// 咀嚼(1:何でも 2:かみにくい 3:ほどんどかめない)を変換する
+
+	str := ""
+	flag := false
+	switch sosyaku {
+	case "":
+		str = ""
+	case "何でも":
+		str = "1"
+	case "かみにくい":
+		str = "2"
+	case "ほとんどかめない":
+		str = "3"
+	default:
+		flag = true
+	}
+
+	if flag {
+		return "", fmt.Errorf("咀嚼変換エラー[%s]", sosyaku)
+	} else {
+		return str, nil
+	}
+
+}
+
+func eat1Conv(eat string) (string, error) {
+	// 食べ方1早食い等(1:速い 2:ふつう 3:遅い)を変換する
+
+	str := ""
+	flag := false
+	switch eat {
+	case "":
+		str = ""
+	case "速い":
+		str = "1"
+	case "普通":
+		str = "2"
+	case "遅い":
+		str = "3"
+	default:
+		flag = true
+	}
+
+	if flag {
+		return "", fmt.Errorf("食べ方1(早食い等)変換エラー[%s]", eat)
+	} else {
+		return str, nil
+	}
+
+}
+
+func eat3Conv(eat string) (string, error) {
+	// 食べ方3間食(1:毎日 2:時々 3:ほとんど摂取しない)を変換する
+
+	str := ""
+	flag := false
+	switch eat {
+	case "":
+		str = ""
+	case "毎日":
+		str = "1"
+	case "時々":
+		str = "2"
+	case "ほとんど摂取しない":
+		str = "3"
+	default:
+		flag = true
+	}
+
+	if flag {
+		return "", fmt.Errorf("食べ方3(間食)変換エラー[%s]", eat)
+	} else {
+		return str, nil
+	}
+
+}
+
+func sakeConv(sake string) (string, error) {
+	// 飲酒習慣(1:毎日 2:時々 3:ほとんど飲まない)を変換する
+
+	str := ""
+	flag := false
+	switch sake {
+	case "":
+		str = ""
+	case "毎日":
+		str = "1"
+	case "時々":
+		str = "2"
+	case "飲まない":
+		str = "3"
+	default:
+		flag = true
+	}
+
+	if flag {
+		return "", fmt.Errorf("飲酒習慣変換エラー[%s]", sake)
+	} else {
+		return str, nil
+	}
+
+}
+
+func sakeryoConv(sakeryo string) (string, error) {
+	// 飲酒量(1:1号未満 2:1～2合未満 3:2～3合 4:3合以上)を変換する
+
+	str := ""
+	flag := false
+	switch sakeryo {
+	case "":
+		str = ""
+	case "１合未満":
+		str = "1"
+	case "１～２合未満":
+		str = "2"
+	case "２～３合未満":
+		str = "3"
+	case "３合以上":
+		str = "4"
+	default:
+		flag = true
+	}
+
+	if flag {
+		return "", fmt.Errorf("飲酒量変換エラー[%s]", sakeryo)
+	} else {
+		return str, nil
+	}
+
+}
+
+func seikatsuConv(seikatsu string) (string, error) {
+	// 生活習慣の改善意志(1:意思なし 2:意志あり(6カ月いない) 3:意志あり(近いうち) 4:取組済み(6カ月未満) 5:取組済み(6カ月以上))を変換する
+
+	str := ""
+	flag := false
+	switch seikatsu {
+	case "":
+		str = ""
+	case "しない":
+		str = "1"
+	case "思う":
+		str = "2"
+	case "始めた":
+		str = "3"
+	case "６ヶ月経過":
+		str = "4"
+	case "６ヶ月以上":
+		str = "5"
+	default:
+		flag = true
+	}
+
+	if flag {
+		return "", fmt.Errorf("生活習慣の改善意志変換エラー[%s]", seikatsu)
+	} else {
+		return str, nil
+	}
+
+}
+
+func hokenConv(hoken string) (string, error) {
+	// 保健指導レベル(1:積極的支援 2:動機付け支援 3:なし 4:判定不能)を変換する
+
+	str := ""
+	flag := false
+	switch hoken {
+	case "":
+		str = ""
+	case "積極的支援レベル":
+		str = "1"
+	case "動機づけ支援レベル":
+		str = "2"
+	case "情報提供レベル":
+		str = "3"
+	case "判定不能":
+		str = "4"
+	default:
+		flag = true
+	}
+
+	if flag {
+		return "", fmt.Errorf("保健指導レベル変換エラー[%s]", hoken)
+	} else {
+		return str, nil
+	}
+
+}
+
+func metaboConv(metabo string) (string, error) {
+	// メタボリックシンドローム判(1:基準該当 2:予備軍該当 3:非該当 4:判定不能)を変換する
+
+	str := ""
+	flag := false
+	switch metabo {
+	case "":
+		str = ""
+	case "基準該当":
+		str = "1"
+	case "予備群該当":
+		str = "2"
+	case "非該当":
+		str = "3"
+	case "判定不能":
+		str = "4"
+	default:
+		flag = true
+	}
+
+	if flag {
+		return "", fmt.Errorf("メタボリックシンドローム判定変換エラー[%s]", metabo)
+	} else {
+		return str, nil
+	}
+
+}
+
+func sogoConv(sogoStr [][2]string) (string, error) {
+
+	str := ""
+	strA := ""
+	strB := ""
+	strC := ""
+	strD := ""
+	strE := ""
+	strF := ""
+	strG := ""
+	strH := ""
+	flag := false
+	for _, v := range sogoStr {
+		switch v[0] {
+		case "":
+
+		case "Ａ":
+			strA = joinStr(strA, v[1])
+		case "Ｂ":
+			strB = joinStr(strB, v[1])
+		case "Ｃ":
+			strC = joinStr(strC, v[1])
+		case "Ｄ":
+			strD = joinStr(strD, v[1])
+		case "Ｅ":
+			strE = joinStr(strE, v[1])
+		case "Ｆ":
+			strF = joinStr(strF, v[1])
+		case "Ｇ":
+			strG = joinStr(strG, v[1])
+		case "Ｈ":
+			strH = joinStr(strH, v[1])
+		default:
+			flag = true
+			str = v[1]
+			break
+		}
+	}
+
+	if flag {
+		return "", fmt.Errorf("総合判定変換エラー[%s]", str)
+	} else {
+		str = joinStr(str, strF)
+		str = joinStr(str, strE)
+		str = joinStr(str, strD)
+		str = joinStr(str, strG)
+		str = joinStr(str, strH)
+		str = joinStr(str, strC)
+		str = joinStr(str, strB)
+		str = joinStr(str, strA)
+		return str, nil
+	}
+
+}
@@ -0,0 +1,67 @@
+package ricohsanai
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCutStr(t *testing.T) {
+	// CP932のバイト数で切る（文字の途中では切らない・区切りがあればそこで切る）
+
+	tests := []struct {
+		in    string
+		limit int
+		kept  string
+		lost  string
+	}{
+		{"あいうえお", 10, "あいうえお", ""},
+		{"あいうえお", 9, "あいうえ", "お"},
+		{"あいうえお", 8, "あいうえ", "お"},
+		{"abcde", 5, "abcde", ""},
+		{"abcde", 4, "abcd", "e"},
+		{"ab漢字", 3, "ab", "漢字"},
+		{"ab漢字", 4, "ab漢", "字"},
+		{"ｱｲｳ", 4, "アイ", "ウ"},
+		{"高血圧、脂肪肝", 12, "高血圧、", "脂肪肝"},
+		{"高血圧 脂肪肝", 12, "高血圧", "脂肪肝"},
+		{"a 漢字漢字漢字", 8, "a 漢字漢", "字漢字"},
+		{"", 10, "", ""},
+	}
+	for _, tt := range tests {
+		kept, lost := cutStr(tt.in, tt.limit)
+		if kept != tt.kept || lost != tt.lost {
+			t.Errorf("cutStr(%q, %d) = %q, %q, want %q, %q", tt.in, tt.limit, kept, lost, tt.kept, tt.lost)
+		}
+		if n := cp932Bytes(kept); n > tt.limit {
+			t.Errorf("cutStr(%q, %d) = %q は %d バイトです", tt.in, tt.limit, kept, n)
+		}
+	}
+}
+
+func TestTruncateWarning(t *testing.T) {
+	// 最大バイト数を超えた列は切り捨てて、切り捨てた文字列を警告(LEN)にする
+
+	header, rows := readTestRows(t)
+	row := setTestValue(header, rows[0], "診察所見1", strings.Repeat("所見", 30)+"末尾")
+	row = setTestValue(header, row, "診察所見2", "")
+	row = setTestValue(header, row, "診察所見3", "")
+
+	c := testConverter()
+	if _, err := c.SetHeader(header); err != nil {
+		t.Fatal(err)
+	}
+	got, issues := c.ConvertRecord(row)
+	col := colIndex("診察所見")
+	if got[col] != strings.Repeat("所見", 25) {
+		t.Errorf("診察所見 %q", got[col])
+	}
+	var lens []Issue
+	for _, is := range issues {
+		if is.Code == CodeLength {
+			lens = append(lens, is)
+		}
+	}
+	if len(lens) != 1 || lens[0].Severity != Warning || !strings.Contains(lens[0].Message, "所見所見所見所見所見末尾") {
+		t.Errorf("LEN の警告 %v", lens)
+	}
+}
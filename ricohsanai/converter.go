@@ -0,0 +1,460 @@
+package ricohsanai
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Reject は変換せずに除外した抽出データの行を表す
+type Reject struct {
+	File    string   // 抽出データのファイル名
+	Line    int      // 抽出データの行番号
+	JusinNo string   // 受診番号
+	Name    string   // 氏名
+	Reason  string   // 除外した理由
+	Items   []string // 抽出データの値(読めなかった行は空)
+}
+
+func (rj Reject) String() string {
+	// ログ用に「ファイル名 行番号 受診番号 氏名: 理由」の形式で返す
+
+	str := fmt.Sprintf("%d行目 %s %s: %s", rj.Line, rj.JusinNo, rj.Name, rj.Reason)
+	if rj.File != "" {
+		str = rj.File + " " + str
+	}
+
+	return str
+}
+
+// Result は Convert の処理結果を表す
+type Result struct {
+	Count      int           // 書き出したレコード件数
+	Issues     []Issue       // 変換時に見つかった問題
+	Header     []string      // 抽出データのタイトル行
+	Rejects    []Reject      // 変換せずに除外した行
+	Files      []FileCount   // 抽出データのファイルごとの件数
+	Submission *Submission   // 書き出したデータの集計
+	Entries    []LedgerEntry // 書き出したレコードの提出台帳の記録
+	Skipped    int           // 提出済みのため書き出さなかった件数
+	Corrected  []Corrected   // 訂正データとして書き出したレコード
+}
+
+// Converter はNWの「A96 三愛グループ健診データ提出用」の抽出データを
+// リコー三愛グループ健康保険組合の健診データ(RB_Ver.1.0)に変換する
+type Converter struct {
+	Courses     *CourseMaster       // コースマスタ
+	Ranges      *RangeMaster        // 基準値マスタ
+	Profile     *Profile            // 施設プロファイル
+	Groups      *GroupMaster        // 所属ルールマスタ
+	Dup         DupPolicy           // 重複したデータの扱い
+	Ledger      *Ledger             // 提出台帳(nilなら提出済みを確認しない)
+	File        string              // 今回の提出ファイル名(提出台帳の記録に使う)
+	NewOnly     bool                // 提出済みのレコードは書き出さない
+	Correct     *Correction         // 訂正データを作成する時の指定
+	Pending     *PendingMaster      // 結果待ちマスタ
+	Chars       *CharMaster         // 置換文字マスタ
+	Layout      *LayoutMaster       // 項目マスタ
+	PendingList map[string][]string // 受診番号ごとの結果待ちの検査名
+	Now         func() time.Time    // 変換の基準日時を返す(作成日・提出日の既定値に使う。変換中に日付が変わらないよう固定した日時を返すとよい)
+	Created     time.Time           // データ作成日(ゼロなら基準日)
+	Submit      time.Time           // データ提出日(ゼロなら基準日)
+
+	lay    layout // 抽出データの項目名と列番号
+	fields int    // 抽出データ１行の列数
+}
+
+func NewConverter() *Converter {
+	c := &Converter{Courses: DefaultCourses(), Ranges: DefaultRanges(), Profile: DefaultProfile(), Groups: DefaultGroups(), Pending: DefaultPending(), Chars: DefaultChars(), Layout: DefaultLayout(), Now: time.Now, lay: defaultLayout()}
+	for _, f := range c.Layout.Fields {
+		if f.Pos >= c.fields {
+			c.fields = f.Pos + 1
+		}
+	}
+
+	return c
+}
+
+func (c *Converter) Days() (time.Time, time.Time) {
+	// 書き出すデータ作成日・提出日を返す（指定が無ければ基準日）
+
+	now := c.Now()
+	created, submit := c.Created, c.Submit
+	if created.IsZero() {
+		created = now
+	}
+	if submit.IsZero() {
+		submit = now
+	}
+
+	return created, submit
+}
+
+func (c *Converter) SetHeader(header []string) ([]string, error) {
+	// 抽出データのタイトル行から項目の列番号を設定する
+	// 位置が変わった項目は警告のメッセージを返し、見つからない項目があればエラーにする
+
+	if len(header) <= 1 {
+		return nil, fmt.Errorf("抽出データのタイトル行がタブ区切りではありません")
+	}
+
+	lay, warns, err := newLayout(c.Layout, header)
+	if err != nil {
+		return warns, err
+	}
+	c.lay = lay
+	c.fields = len(header)
+	return warns, nil
+}
+
+func (c *Converter) CheckRecord(items []string) error {
+	// 抽出データ１行分の列数を確認する
+
+	if len(items) < c.fields {
+		return fmt.Errorf("列数が足りません(%d列/%d列)", len(items), c.fields)
+	}
+
+	if len(items) > c.fields {
+		return fmt.Errorf("列数が多すぎます(%d列/%d列)。値にタブが含まれていないか確認してください", len(items), c.fields)
+	}
+
+	return nil
+}
+
+type record struct {
+	c       *Converter
+	lay     layout
+	items   []string
+	rule    *GroupRule // 所属cd1の所属ルール
+	jusinNo string
+	name    string
+	issues  []Issue
+	pending *[]string // 結果待ちの検査名(確認した後)
+	created string    // データ作成日
+	submit  string    // データ提出日
+	age     *Age      // 年齢基準ごとの年齢(計算した後)
+	col     *column   // 変換中の列
+	v       []string  // 変換中の列の抽出データの値
+}
+
+func (c *Converter) newRecord(items []string) *record {
+	// 抽出データ１行分の record を作成する
+
+	rec := &record{c: c, lay: c.lay, items: items}
+	created, submit := c.Days()
+	rec.created, rec.submit = created.Format("2006/01/02"), submit.Format("2006/01/02")
+	rule, ruleErr := c.Groups.Find(rec.raw("所属cd1"))
+	rec.rule = rule
+	rec.jusinNo = rec.get("受診番号") // ログ用　受診番号 氏名
+	rec.name = rec.get("漢字氏名")
+	rec.check(issueOf(CodeGroup, "所属cd1", rec.raw("所属cd1"), ruleErr))
+
+	return rec
+}
+
+func (rec *record) check(err error) {
+	// エラーがあれば問題として記録する
+
+	if err == nil {
+		return
+	}
+
+	is := Issue{Severity: Error, Code: CodeConvert, JusinNo: rec.jusinNo, Name: rec.name, JDay: rec.get("受診日"), Course: rec.get("コース名"), Message: err.Error()}
+	if rec.col != nil {
+		is.Column = rec.col.title
+		if len(rec.col.src) > 0 {
+			is.Src = rec.col.src[0]
+			is.Value = rec.v[0]
+		}
+	}
+
+	if ie, ok := err.(*issueError); ok {
+		is.Severity = ie.sev
+		is.Code = ie.code
+		if ie.src != "" {
+			is.Src = ie.src
+			is.Value = ie.value
+		}
+	}
+
+	rec.issues = append(rec.issues, is)
+}
+
+func (rec *record) get(name string) string {
+	// 項目名で抽出データの値を返す（空欄なら所属ルールの既定値を返す）
+
+	str := rec.raw(name)
+	if str == "" && rec.rule != nil {
+		str = rec.rule.Defaults[name]
+	}
+
+	return str
+}
+
+func (rec *record) raw(name string) string {
+	// 項目名で抽出データの値をそのまま返す
+
+	i, ok := rec.lay[name]
+	if !ok || i >= len(rec.items) {
+		return ""
+	}
+
+	return rec.items[i]
+}
+
+// Input は抽出データのファイル１つ分
+type Input struct {
+	Name string // ファイル名（ログ・集計用）
+	R    io.Reader
+}
+
+// FileCount は抽出データのファイルごとの件数
+type FileCount struct {
+	Name    string
+	Rows    int // 読み込んだ行数(タイトル行を除く)
+	Count   int // 書き出したレコード件数
+	Rejects int // 除外した行数
+}
+
+func (c *Converter) Convert(r io.Reader, w io.Writer) (*Result, error) {
+	// タブ区切りの抽出データを読み込み、変換したCSVを書き出す
+
+	return c.ConvertFiles([]Input{{R: r}}, w)
+}
+
+func (c *Converter) ConvertFiles(inputs []Input, w io.Writer) (*Result, error) {
+	// 複数の抽出データを順に読み込み、１つのCSVにまとめて書き出す
+	// 重複を確認してから書き出すので、変換した行はいったん全部ためておく
+
+	res := &Result{Submission: &Submission{Facility: c.Profile.Get("健診機関名称")}}
+
+	// writerの準備
+	writer := csv.NewWriter(cp932Writer(w))
+	writer.Comma = ','
+	writer.UseCRLF = true
+
+	// 列定義を確認する
+	if err := verifyColumns(); err != nil {
+		return res, err
+	}
+	if c.Correct != nil {
+		if err := c.Correct.Check(); err != nil {
+			return res, err
+		}
+	}
+
+	var rows []converted
+	for i, in := range inputs {
+		res.Files = append(res.Files, FileCount{Name: in.Name})
+		got, err := c.readFile(in, i, res)
+		rows = append(rows, got...)
+		if err != nil {
+			if in.Name != "" {
+				err = fmt.Errorf("%s: %s", in.Name, err)
+			}
+			return res, err
+		}
+	}
+
+	// 重複を確認する
+	keep, dups := c.dedup(rows, inputs)
+	if c.Dup == DupAbort && len(rows) > 0 {
+		before := len(res.Issues) // 読み込みでの問題(タイトル行など)は残す
+		n := 0
+		for i := range rows {
+			res.Issues = append(res.Issues, rows[i].issues...)
+			res.Issues = append(res.Issues, dups[i]...)
+			if !keep[i] {
+				n++
+			}
+		}
+		if n > 0 {
+			return res, fmt.Errorf("重複したデータが%d件あるため中止しました。残すデータは -dup first(先のデータ) か -dup latest(後のデータ) で選べます", n)
+		}
+		res.Issues = res.Issues[:before]
+	}
+
+	// タイトル行を書きだす
+	title := Title()
+	if err := writer.Write(title); err != nil {
+		return res, err
+	}
+
+	for i, cv := range rows {
+		if !keep[i] { // 除外した重複データは重複の問題だけ記録する
+			res.Issues = append(res.Issues, dups[i]...)
+			continue
+		}
+
+		// 提出台帳で提出済みか確認する
+		entry := ledgerEntry(cv.rec, cv.row)
+		sent, found := LedgerEntry{}, false
+		if c.Ledger != nil {
+			sent, found = c.Ledger.Find(entry)
+		}
+		changed := found && sent.Hash != entry.Hash
+
+		// 訂正データは指定したレコードだけ書き出す
+		if c.Correct != nil && !c.Correct.selects(cv.rec.jusinNo, changed) {
+			continue
+		}
+
+		res.Issues = append(res.Issues, cv.issues...)
+		res.Issues = append(res.Issues, dups[i]...)
+
+		pending := cv.row[kubunCol] != kubunDone
+		if c.Correct != nil {
+			res.Corrected = append(res.Corrected, Corrected{JusinNo: cv.rec.jusinNo, Name: cv.rec.name, JDay: entry.JDay, Sent: sent, Found: found, Row: append([]string(nil), cv.row...)})
+			if !pending { // 結果待ちのレコードは未完了のまま
+				cv.row[kubunCol] = c.Correct.Kubun
+			}
+			entry = ledgerEntry(cv.rec, cv.row) // 提出台帳には書き出す行を記録する
+		} else if found {
+			res.Issues = append(res.Issues, cv.rec.sentIssue(sent, changed))
+			if c.NewOnly {
+				res.Skipped++
+				continue
+			}
+		}
+
+		if err := writer.Write(cv.row); err != nil { // 1行書き出す
+			return res, err
+		}
+		res.Count++
+		res.Files[cv.file].Count++
+		res.Submission.add(cv.row, pending)
+		res.Entries = append(res.Entries, entry)
+	}
+
+	if c.Correct != nil {
+		res.Issues = append(res.Issues, c.Correct.missing(res.Corrected)...)
+	}
+
+	for _, fc := range res.Files {
+		res.Submission.Files = append(res.Submission.Files, Tally{Name: fc.Name, Count: fc.Count})
+	}
+
+	writer.Flush()
+	return res, writer.Error()
+}
+
+// converted は変換した抽出データの１行
+type converted struct {
+	file   int // inputs の番号
+	line   int // 抽出データの行番号
+	rec    *record
+	row    []string
+	issues []Issue
+}
+
+func (c *Converter) readFile(in Input, file int, res *Result) ([]converted, error) {
+	// 抽出データのファイル１つ分を読み込んで変換する
+
+	fc := &res.Files[file]
+
+	// readerの準備
+	// 抽出データは引用符の無いタブ区切りなので、"を含む値もそのまま読む
+	reader := newTsvReader(cp932Reader(in.R))
+
+	// タイトル行をよみだす
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("タイトル行がありません")
+	} else if err != nil {
+		return nil, err
+	}
+	if res.Header == nil {
+		res.Header = header
+	}
+	warns, err := c.SetHeader(header)
+	if err != nil {
+		return nil, err
+	}
+	for _, msg := range warns {
+		if in.Name != "" {
+			msg = in.Name + " " + msg
+		}
+		res.Issues = append(res.Issues, Issue{Severity: Warning, Code: CodeLayout, Message: "タイトル行: " + msg})
+	}
+
+	var rows []converted
+	for {
+		items, err := reader.Read() // １行読みだす
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return rows, err
+		}
+		fc.Rows++
+
+		// 列数が合わない行は除外して次の行へ進む
+		line := reader.line
+		if err := c.CheckRecord(items); err != nil {
+			rec := c.newRecord(items)
+			res.Rejects = append(res.Rejects, Reject{File: in.Name, Line: line, JusinNo: rec.jusinNo, Name: rec.name, Reason: err.Error(), Items: items})
+			fc.Rejects++
+			continue
+		}
+
+		rec := c.newRecord(items)
+		writeItems, issues := c.convertRecord(rec)
+
+		// 列数とカンマ位置を確認する
+		if err := VerifyRow(writeItems); err != nil {
+			return rows, fmt.Errorf("%s %s: %s", rec.jusinNo, rec.name, err)
+		}
+
+		rows = append(rows, converted{file: file, line: line, rec: rec, row: writeItems, issues: issues})
+	}
+
+	return rows, nil
+}
+
+// tsvReader はNWの抽出データ(タブ区切り・引用符なし)を１行ずつ読む
+type tsvReader struct {
+	r    *bufio.Reader
+	line int // 最後に読んだ行の行番号
+}
+
+func newTsvReader(r io.Reader) *tsvReader {
+	return &tsvReader{r: bufio.NewReader(r)}
+}
+
+func (t *tsvReader) Read() ([]string, error) {
+	// 次の行をタブで分けて返す（空行は読み飛ばす）
+
+	for {
+		str, err := t.r.ReadString('\n')
+		if str == "" && err != nil {
+			return nil, err
+		}
+		t.line++
+
+		str = strings.TrimRight(str, "\r\n")
+		if str == "" {
+			continue
+		}
+
+		return strings.Split(str, "\t"), nil
+	}
+}
+
+func (c *Converter) ConvertRecord(items []string) ([]string, []Issue) {
+	// 抽出データ１行分を変換する
+
+	return c.convertRecord(c.newRecord(items))
+}
+
+func (c *Converter) convertRecord(rec *record) ([]string, []Issue) {
+	// record を提出データの１行に変換する
+
+	writeItems := make([]string, 0, len(columns))
+	for _, col := range columns {
+		writeItems = append(writeItems, col.value(rec))
+	}
+
+	return writeItems, rec.issues
+}
@@ -0,0 +1,250 @@
+package ricohsanai
+
+import (
+	"bytes"
+	"encoding/csv"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "testdata の期待値を書き直す")
+
+// testdata/a96.txt は A96 の抽出データ(タイトル行と3行)、a96.csv はその変換結果
+func testConverter() *Converter {
+	c := NewConverter()
+	c.Now = func() time.Time { return time.Date(2024, 7, 1, 9, 0, 0, 0, time.Local) }
+
+	return c
+}
+
+func TestConvert(t *testing.T) {
+	// 抽出データを変換して、期待値のCSVと同じになることを確認する
+
+	in, err := os.ReadFile(filepath.Join("testdata", "a96.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	res, err := testConverter().Convert(bytes.NewReader(in), &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Files) != 1 || res.Files[0].Rows != 3 || len(res.Rejects) != 0 {
+		t.Fatalf("読込 %+v 除外 %d 行", res.Files, len(res.Rejects))
+	}
+
+	golden := filepath.Join("testdata", "a96.csv")
+	if *update {
+		if err := os.WriteFile(golden, out.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(out.Bytes(), want) {
+		return
+	}
+	got := readTestCSV(t, out.Bytes())
+	wantRows := readTestCSV(t, want)
+	if len(got) != len(wantRows) {
+		t.Fatalf("行数 %d, want %d", len(got), len(wantRows))
+	}
+	for i := range got {
+		for j := range columns {
+			if got[i][j] != wantRows[i][j] {
+				t.Errorf("%d行目 %d列[%s] = %q, want %q", i+1, j+1, columns[j].title, got[i][j], wantRows[i][j])
+			}
+		}
+	}
+}
+
+func TestConvertRecord(t *testing.T) {
+	// １行ずつの変換が Convert と同じ結果になることを確認する
+
+	in, err := os.ReadFile(filepath.Join("testdata", "a96.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile(filepath.Join("testdata", "a96.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantRows := readTestCSV(t, want)
+
+	reader := newTsvReader(cp932Reader(bytes.NewReader(in)))
+	header, err := reader.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := testConverter()
+	if _, err := c.SetHeader(header); err != nil {
+		t.Fatal(err)
+	}
+	for i := range wantRows {
+		items, err := reader.Read()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, _ := c.ConvertRecord(items)
+		if err := VerifyRow(got); err != nil {
+			t.Fatalf("%d行目: %s", i+1, err)
+		}
+		for j := range columns {
+			if got[j] != wantRows[i][j] {
+				t.Errorf("%d行目 %d列[%s] = %q, want %q", i+1, j+1, columns[j].title, got[j], wantRows[i][j])
+			}
+		}
+	}
+}
+
+func TestConvertRecordDays(t *testing.T) {
+	// 作成日・提出日は ConvertFiles を通さなくても基準日になる
+
+	in, err := os.ReadFile(filepath.Join("testdata", "a96.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader := newTsvReader(cp932Reader(bytes.NewReader(in)))
+	reader.Read()
+	items, err := reader.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := testConverter()
+	c.Submit = time.Date(2024, 7, 10, 0, 0, 0, 0, time.Local)
+	row, _ := c.ConvertRecord(items)
+	for i, col := range columns {
+		switch col.title {
+		case "データ作成日":
+			if row[i] != "2024/07/01" {
+				t.Errorf("データ作成日 %q", row[i])
+			}
+		case "データ提出日":
+			if row[i] != "2024/07/10" {
+				t.Errorf("データ提出日 %q", row[i])
+			}
+		}
+	}
+}
+
+func TestConvertFiles(t *testing.T) {
+	// 複数の抽出データを指定した順に１つにまとめ、ファイルをまたいだ重複も確認する
+
+	header, rows := readTestRows(t)
+	again := setTestValue(header, rows[0], "受診番号", "100009")
+	nameCol := colIndex("漢字氏名")
+
+	c := testConverter()
+	var out bytes.Buffer
+	res, err := c.ConvertFiles([]Input{testInput(t, "a.txt", header, rows[0], rows[1]), testInput(t, "b.txt", header, rows[2], again)}, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, row := range readTestCSV(t, out.Bytes()) {
+		names = append(names, row[nameCol])
+	}
+	if want := []string{"山田　太郎0", "山田　太郎1", "山田　太郎2"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("書き出した行 %v, want %v", names, want)
+	}
+	if n := countIssues(res.Issues, CodeDuplicate); n != 1 {
+		t.Errorf("DUP %d件, want 1件", n)
+	}
+
+	want := []FileCount{{Name: "a.txt", Rows: 2, Count: 2}, {Name: "b.txt", Rows: 2, Count: 1}}
+	if !reflect.DeepEqual(res.Files, want) {
+		t.Errorf("ファイルごとの件数 %+v, want %+v", res.Files, want)
+	}
+	if tally := res.Submission.Files; len(tally) != 2 || tally[0].Count != 2 || tally[1].Count != 1 {
+		t.Errorf("提出データのファイルごとの件数 %+v", tally)
+	}
+
+	// タイトル行が違うファイルがあればファイル名をつけてエラー
+	short := append([]string(nil), header[:10]...)
+	_, err = testConverter().ConvertFiles([]Input{testInput(t, "a.txt", header, rows[0]), testInput(t, "b.txt", short)}, &bytes.Buffer{})
+	if err == nil || !strings.HasPrefix(err.Error(), "b.txt: ") {
+		t.Errorf("タイトル行が違うファイル err %v", err)
+	}
+}
+
+func readTestCSV(t *testing.T, b []byte) [][]string {
+	// 変換結果のCSVを読む（タイトル行は除く）
+
+	t.Helper()
+	r := csv.NewReader(cp932Reader(bytes.NewReader(b)))
+	r.FieldsPerRecord = len(columns)
+	rows, err := r.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) == 0 {
+		t.Fatal("タイトル行がありません")
+	}
+
+	return rows[1:]
+}
+
+func readTestRows(t *testing.T) ([]string, [][]string) {
+	// testdata/a96.txt のタイトル行とデータ行を返す
+
+	t.Helper()
+	in, err := os.ReadFile(filepath.Join("testdata", "a96.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader := newTsvReader(cp932Reader(bytes.NewReader(in)))
+	header, err := reader.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rows [][]string
+	for {
+		items, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		rows = append(rows, items)
+	}
+
+	return header, rows
+}
+
+func testInput(t *testing.T, name string, header []string, rows ...[]string) Input {
+	// タイトル行とデータ行から抽出データ(CP932のタブ区切り)を作る
+
+	t.Helper()
+	lines := []string{strings.Join(header, "\t")}
+	for _, row := range rows {
+		lines = append(lines, strings.Join(row, "\t"))
+	}
+	data, err := cp932.NewEncoder().String(strings.Join(lines, "\r\n") + "\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return Input{Name: name, R: strings.NewReader(data)}
+}
+
+func setTestValue(header []string, row []string, name string, value string) []string {
+	// データ行の複製の項目名の値を変えて返す
+
+	row = append([]string(nil), row...)
+	for i, h := range header {
+		if h == name {
+			row[i] = value
+		}
+	}
+
+	return row
+}
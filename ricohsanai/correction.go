@@ -0,0 +1,212 @@
+package ricohsanai
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// kubunCol はデータ登録完了区分の列
+var kubunCol = colIndex("データ登録完了区分")
+
+// Correction は訂正データを作成する時の指定
+type Correction struct {
+	JusinNos []string // 訂正する受診番号(空なら提出台帳で提出後に内容が変わったレコード)
+	Kubun    string   // 訂正データのデータ登録完了区分
+}
+
+// Corrected は訂正データとして書き出したレコード
+type Corrected struct {
+	JusinNo string
+	Name    string
+	JDay    string
+	Sent    LedgerEntry // 前回提出した時の提出台帳の記録
+	Found   bool        // 提出台帳にあった
+	Row     []string    // 書き出した行(データ登録完了区分は変える前)
+}
+
+// Change は訂正データで変わった項目
+type Change struct {
+	Column string
+	Old    string
+	New    string
+}
+
+func ReadJusinList(r io.Reader) ([]string, error) {
+	// 受診番号の一覧を読み込む（改行・カンマ・タブ・空白区切り。#から後はコメント。shift-JISでもUTF-8でも読める）
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data, err = decodeText(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		list = append(list, strings.FieldsFunc(line, func(c rune) bool {
+			return c == ',' || c == '\t' || c == ' ' || c == '\r' || c == '　'
+		})...)
+	}
+
+	return list, scanner.Err()
+}
+
+func (cr *Correction) Check() error {
+	// 訂正データの登録完了区分を確認する（通常のデータと区別できる値にする）
+
+	switch cr.Kubun {
+	case "":
+		return fmt.Errorf("施設プロファイルの「訂正データの登録完了区分」が空欄です。健保に確認した値を設定してください")
+	case kubunDone:
+		return fmt.Errorf("施設プロファイルの「訂正データの登録完了区分」が登録完了(%s)と同じです。通常のデータと区別できる値を健保に確認して設定してください", kubunDone)
+	}
+
+	return nil
+}
+
+func (cr *Correction) selects(jusinNo string, changed bool) bool {
+	// 訂正データに書き出すレコードならtrueを返す
+
+	if len(cr.JusinNos) == 0 {
+		return changed
+	}
+
+	return contains(cr.JusinNos, jusinNo)
+}
+
+func (cr *Correction) missing(done []Corrected) []Issue {
+	// 指定した受診番号が抽出データに無ければ問題として返す
+
+	var issues []Issue
+	for _, no := range cr.JusinNos {
+		found := false
+		for _, d := range done {
+			if d.JusinNo == no {
+				found = true
+				break
+			}
+		}
+		if !found {
+			issues = append(issues, Issue{
+				Severity: Error,
+				Code:     CodeCorrect,
+				JusinNo:  no,
+				Src:      "受診番号",
+				Value:    no,
+				Message:  fmt.Sprintf("訂正する受診番号[%s]が抽出データにありません", no),
+			})
+		}
+	}
+
+	return issues
+}
+
+func ReadSubmittedRows(r io.Reader) (map[string][]string, error) {
+	// 前回の提出ファイルを読み込み、行のハッシュごとの行を返す
+
+	reader := csv.NewReader(cp932Reader(r))
+	reader.FieldsPerRecord = -1
+
+	rows := map[string][]string{}
+	first := true
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if first { // タイトル行
+			first = false
+			continue
+		}
+		rows[rowHash(row)] = row
+	}
+
+	return rows, nil
+}
+
+func Changes(old []string, row []string) []Change {
+	// 前回提出した行からの変更を返す（作成日・提出日は除く）
+
+	var changes []Change
+	for i, col := range columns {
+		if contains([]string{"データ作成日", "データ提出日"}, col.title) {
+			continue
+		}
+		o, n := "", ""
+		if i < len(old) {
+			o = old[i]
+		}
+		if i < len(row) {
+			n = row[i]
+		}
+		if o != n {
+			changes = append(changes, Change{Column: fmt.Sprintf("%d列目 %s", i+1, strings.TrimSpace(col.title)), Old: o, New: n})
+		}
+	}
+
+	return changes
+}
+
+func CorrectionLines(s *Submission, done []Corrected, prev map[string]map[string][]string) []string {
+	// 訂正データの送付状の内容を返す
+	// prev は前回の提出ファイル名ごとの ReadSubmittedRows の結果
+
+	lines := []string{
+		"リコー三愛グループ健康保険組合　健診データ訂正",
+		"",
+		"医療機関名　　　：" + s.Facility,
+		"提出日　　　　　：" + s.SubmitDay,
+		fmt.Sprintf("訂正レコード件数：%d件", len(done)),
+	}
+
+	sorted := append([]Corrected(nil), done...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].JusinNo < sorted[j].JusinNo })
+	for _, d := range sorted {
+		lines = append(lines, "", fmt.Sprintf("受診番号 %s　%s　受診日 %s", d.JusinNo, d.Name, d.JDay))
+		if !d.Found {
+			lines = append(lines, "　前回提出：提出台帳に記録がありません")
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("　前回提出：%s %s", d.Sent.File, d.Sent.Time))
+
+		old, ok := prev[d.Sent.File][d.Sent.Hash]
+		if !ok {
+			lines = append(lines, "　前回の提出ファイルが無いため、変更した項目はわかりません")
+			continue
+		}
+		changes := Changes(old, d.Row)
+		if len(changes) == 0 {
+			lines = append(lines, "　変更した項目はありません")
+		}
+		for _, ch := range changes {
+			lines = append(lines, fmt.Sprintf("　%s：%s → %s", ch.Column, ch.Old, ch.New))
+		}
+	}
+
+	return lines
+}
+
+func WriteCorrections(w io.Writer, s *Submission, done []Corrected, prev map[string]map[string][]string) error {
+	// 訂正データの送付状をshift-JISのテキストで書き出す
+
+	tw := cp932Writer(w)
+	if _, err := io.WriteString(tw, strings.Join(CorrectionLines(s, done, prev), "\r\n")+"\r\n"); err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
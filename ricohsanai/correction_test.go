@@ -0,0 +1,103 @@
+package ricohsanai
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCorrectChanged(t *testing.T) {
+	// 提出後に内容が変わったレコードだけを訂正データにし、次の変換では提出済み(SENT)になる
+
+	header, rows := readTestRows(t)
+	ledger := &Ledger{}
+
+	c := testConverter()
+	c.Ledger, c.File = ledger, "first.csv"
+	res, err := c.ConvertFiles([]Input{testInput(t, "a.txt", header, rows...)}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ledger.Add(res.Entries, "first.csv", "2024/07/01 09:00")
+
+	fixed := append([][]string(nil), rows...)
+	fixed[1] = setTestValue(header, rows[1], "漢字氏名", "山田　次郎1")
+
+	c = testConverter()
+	c.Ledger, c.File = ledger, "fix.csv"
+	c.Correct = &Correction{Kubun: "3"}
+	var out bytes.Buffer
+	res, err = c.ConvertFiles([]Input{testInput(t, "a.txt", header, fixed...)}, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	written := readTestCSV(t, out.Bytes())
+	if len(written) != 1 || written[0][colIndex("漢字氏名")] != "山田　次郎1" {
+		t.Fatalf("訂正データ %d件 %v", len(written), written)
+	}
+	if kubun := written[0][kubunCol]; kubun != "3" {
+		t.Errorf("データ登録完了区分 %q, want %q", kubun, "3")
+	}
+	if len(res.Corrected) != 1 || !res.Corrected[0].Found || res.Corrected[0].Sent.File != "first.csv" {
+		t.Errorf("訂正したレコード %+v", res.Corrected)
+	}
+	if len(res.Entries) != 1 || res.Entries[0].Hash != rowHash(written[0]) {
+		t.Errorf("提出台帳の記録が書き出した行と違います")
+	}
+	if res.Submission.Pending != 0 {
+		t.Errorf("未完了 %d件, want 0件", res.Submission.Pending)
+	}
+	ledger.Add(res.Entries, "fix.csv", "2024/07/02 09:00")
+
+	// 訂正した後は、変わったレコードも提出済み
+	c = testConverter()
+	c.Ledger, c.File = ledger, "third.csv"
+	res, err = c.ConvertFiles([]Input{testInput(t, "a.txt", header, fixed...)}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := countIssues(res.Issues, CodeChanged); n != 0 {
+		t.Errorf("訂正した後も CHANGED %d件", n)
+	}
+	if n := countIssues(res.Issues, CodeSent); n != len(rows) {
+		t.Errorf("SENT %d件, want %d件", n, len(rows))
+	}
+}
+
+func TestCorrectJusinNos(t *testing.T) {
+	// 受診番号を指定した時は指定したレコードだけ書き出し、無い受診番号はエラーにする
+
+	header, rows := readTestRows(t)
+	c := testConverter()
+	c.Correct = &Correction{JusinNos: []string{"100001", "999999"}, Kubun: "3"}
+	var out bytes.Buffer
+	res, err := c.ConvertFiles([]Input{testInput(t, "a.txt", header, rows...)}, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	written := readTestCSV(t, out.Bytes())
+	if len(written) != 1 || written[0][colIndex("漢字氏名")] != "山田　太郎1" {
+		t.Errorf("訂正データ %v", written)
+	}
+	if n := countIssues(res.Issues, CodeCorrect); n != 1 {
+		t.Errorf("CORRECT %d件, want 1件", n)
+	}
+}
+
+func TestCorrectionCheck(t *testing.T) {
+	// 訂正データの登録完了区分は空欄・登録完了と同じ値にできない
+
+	for _, kubun := range []string{"", kubunDone} {
+		if err := (&Correction{Kubun: kubun}).Check(); err == nil {
+			t.Errorf("登録完了区分[%s]がエラーになりません", kubun)
+		}
+		c := testConverter()
+		c.Correct = &Correction{JusinNos: []string{"100001"}, Kubun: kubun}
+		header, rows := readTestRows(t)
+		if _, err := c.ConvertFiles([]Input{testInput(t, "a.txt", header, rows...)}, &bytes.Buffer{}); err == nil {
+			t.Errorf("登録完了区分[%s]で訂正データを作成しました", kubun)
+		}
+	}
+	if err := (&Correction{Kubun: "3"}).Check(); err != nil {
+		t.Error(err)
+	}
+}
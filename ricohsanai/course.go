@@ -0,0 +1,205 @@
+package ricohsanai
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+//go:embed master/course.csv
+var defaultCourseCSV []byte
+
+// CourseRule はコースマスタの１行
+type CourseRule struct {
+	Code      string // NWのコースコード
+	Name      string // NWのコース名
+	Age       string // 年齢条件
+	AgeBasis  string // 年齢基準(受診日 年度末)
+	RicohCd   string // リコーのコースコード
+	RicohName string // リコーのコース名
+	From      string // 適用開始(受診日)
+	To        string // 適用終了(受診日)
+	Line      int    // コースマスタの行番号
+}
+
+// 年齢基準
+const (
+	basisExam   = "受診日" // 受診日の年齢
+	basisFiscal = "年度末" // 受診した年度の末日(3月31日)の年齢
+)
+
+// Age は年齢基準ごとの年齢(-1は年齢が分からない)
+type Age struct {
+	Exam      int  // 受診日の年齢
+	FiscalEnd int  // 年度末の年齢
+	Calc      bool // 生年月日・受診日から計算した
+}
+
+func (a Age) of(basis string) int {
+	// 年齢基準の年齢を返す
+
+	if basis == basisFiscal {
+		return a.FiscalEnd
+	}
+
+	return a.Exam
+}
+
+// CourseMaster はNWのコースからリコーのコースを決めるコースマスタ
+type CourseMaster struct {
+	Version string
+	Rules   []CourseRule
+}
+
+func DefaultCourses() *CourseMaster {
+	// 内蔵のコースマスタを返す
+
+	m, err := LoadCourses(bytes.NewReader(defaultCourseCSV))
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+func DefaultCoursesCSV() []byte {
+	// 内蔵のコースマスタのファイル内容を返す
+
+	return defaultCourseCSV
+}
+
+func LoadCourses(r io.Reader) (*CourseMaster, error) {
+	// コースマスタを読み込む
+
+	const name = "コースマスタ"
+	t, err := readTable(r, name)
+	if err != nil {
+		return nil, err
+	}
+
+	pos, err := t.columns(name, "コースコード", "コース名", "年齢条件", "リコーコード", "リコーコース名", "適用開始", "適用終了")
+	if err != nil {
+		return nil, err
+	}
+
+	// 年齢基準の列は無くてもよい（無ければ受診日の年齢）
+	basisPos := t.optional("年齢基準")
+
+	m := &CourseMaster{Version: t.version}
+	for i, items := range t.rows {
+		rule := CourseRule{
+			Code:      items[pos[0]],
+			Name:      items[pos[1]],
+			Age:       items[pos[2]],
+			RicohCd:   items[pos[3]],
+			RicohName: items[pos[4]],
+			From:      strings.Replace(items[pos[5]], "-", "/", -1),
+			To:        strings.Replace(items[pos[6]], "-", "/", -1),
+			Line:      t.lines[i],
+		}
+
+		if basisPos >= 0 {
+			rule.AgeBasis = items[basisPos]
+		}
+
+		if rule.Code == "" || rule.Name == "" {
+			return nil, fmt.Errorf("%s %d行目: コースコードとコース名は必須です", name, rule.Line)
+		}
+		if _, err := ageMatch(rule.Age, 0); err != nil {
+			return nil, fmt.Errorf("%s %d行目: %s", name, rule.Line, err)
+		}
+		switch rule.AgeBasis {
+		case "", basisExam, basisFiscal:
+		default:
+			return nil, fmt.Errorf("%s %d行目: 年齢基準[%s]は「%s」か「%s」で入力してください", name, rule.Line, rule.AgeBasis, basisExam, basisFiscal)
+		}
+		if (rule.RicohCd == "") != (rule.RicohName == "") {
+			return nil, fmt.Errorf("%s %d行目: リコーコードとリコーコース名は両方入力してください", name, rule.Line)
+		}
+
+		m.Rules = append(m.Rules, rule)
+	}
+
+	return m, nil
+}
+
+func (m *CourseMaster) Classify(cd string, name string, age Age, jday string) (string, string, error) {
+	// コースコードとコース名を変換する
+	// 年齢条件は行ごとの年齢基準の年齢で判定する（年齢が分からなければ年齢条件のある行でエラーにする）
+
+	steps := m.Explain(cd, name, age, jday)
+	nameFlag := true
+	for _, st := range steps {
+		switch st.Result {
+		case stepMatch:
+			return st.Rule.RicohCd, st.Rule.RicohName, nil
+		case stepUnknown:
+			return "", "", fmt.Errorf("コース変換エラー(%s_%s)年齢が分からないためコースを決められません。", cd, name)
+		case stepName:
+			// コース名が違う行だけならコース名のエラー
+		default:
+			nameFlag = false
+		}
+	}
+
+	if len(steps) == 0 {
+		return "", "", fmt.Errorf("コース変換エラー(%s_%s)コースマスタのコースコードを確認してください。", cd, name)
+	} else if nameFlag {
+		return "", "", fmt.Errorf("コース変換エラー(%s_%s)コースマスタのコース名を確認してください。", cd, name)
+	}
+
+	return "", "", fmt.Errorf("コース変換エラー(%s_%s)コースマスタのコース登録の仕様を確認してください。", cd, name)
+}
+
+func (rule CourseRule) valid(jday string) bool {
+	// 受診日が適用期間内ならtrueを返す
+
+	jday = normDay(jday)
+	if rule.From != "" && jday < rule.From {
+		return false
+	}
+	if rule.To != "" && jday > rule.To {
+		return false
+	}
+
+	return true
+}
+
+func ageMatch(cond string, age int) (bool, error) {
+	// 年齢条件に合えばtrueを返す（条件は空白区切りで全て満たすこと）
+
+	match := true
+	for _, c := range strings.Fields(cond) {
+		ok := false
+		switch {
+		case c == "対象外":
+			ok = false
+		case c == "節目":
+			ok = age >= 40 && age <= 70 && age%5 == 0
+		case c == "5の倍数以外":
+			ok = age%5 != 0
+		case strings.HasPrefix(c, "<="), strings.HasPrefix(c, ">="):
+			n, err := strconv.Atoi(c[2:])
+			if err != nil {
+				return false, fmt.Errorf("年齢条件[%s]が不正です", cond)
+			}
+			ok = (c[0] == '<' && age <= n) || (c[0] == '>' && age >= n)
+		case strings.HasPrefix(c, "<"), strings.HasPrefix(c, ">"), strings.HasPrefix(c, "="):
+			n, err := strconv.Atoi(c[1:])
+			if err != nil {
+				return false, fmt.Errorf("年齢条件[%s]が不正です", cond)
+			}
+			ok = (c[0] == '<' && age < n) || (c[0] == '>' && age > n) || (c[0] == '=' && age == n)
+		default:
+			return false, fmt.Errorf("年齢条件[%s]が不正です", cond)
+		}
+		if !ok {
+			match = false
+		}
+	}
+
+	return match, nil
+}
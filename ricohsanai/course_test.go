@@ -0,0 +1,132 @@
+package ricohsanai
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAgeMatch(t *testing.T) {
+	// 年齢条件は空白区切りで全てを満たすこと
+
+	tests := []struct {
+		cond string
+		age  int
+		want bool
+	}{
+		{"", 20, true},
+		{"<=34", 34, true},
+		{"<=34", 35, false},
+		{">=36", 36, true},
+		{"<35", 35, false},
+		{">35", 36, true},
+		{"=35", 35, true},
+		{"=35", 36, false},
+		{"節目", 40, true},
+		{"節目", 35, false},
+		{"節目", 75, false},
+		{"5の倍数以外", 41, true},
+		{"5の倍数以外", 45, false},
+		{">=36 5の倍数以外", 37, true},
+		{">=36 5の倍数以外", 40, false},
+		{"対象外", 40, false},
+	}
+	for _, tt := range tests {
+		got, err := ageMatch(tt.cond, tt.age)
+		if err != nil {
+			t.Errorf("ageMatch(%q, %d): %v", tt.cond, tt.age, err)
+		} else if got != tt.want {
+			t.Errorf("ageMatch(%q, %d) = %v, want %v", tt.cond, tt.age, got, tt.want)
+		}
+	}
+
+	for _, cond := range []string{"<=", "=abc", "35", "40歳以上"} {
+		if _, err := ageMatch(cond, 40); err == nil {
+			t.Errorf("ageMatch(%q) がエラーになりません", cond)
+		}
+	}
+}
+
+func TestClassify(t *testing.T) {
+	// 内蔵のコースマスタで年齢からリコーのコースを決める
+
+	m := DefaultCourses()
+	years := func(n int) Age { return Age{Exam: n, FiscalEnd: n} }
+
+	tests := []struct {
+		cd, name string
+		age      Age
+		wantCd   string
+		wantName string
+		err      string
+	}{
+		{"98009001000011", "リコー_総合Ａ", years(35), "31", "総合健診A(35歳)", ""},
+		{"98009001000011", "リコー_総合Ａ", years(40), "32", "総合健診A(節目年齢)", ""},
+		{"98009001000011", "リコー_総合Ａ", years(41), "", "", "コース登録の仕様"},
+		{"98009001000012", "リコー_総合Ｂ", years(40), "", "", "コース登録の仕様"},
+		{"98009001000012", "リコー_総合Ｂ", years(41), "33", "総合健診B", ""},
+		{"98009001000017", "リコー_基本(ｽﾏｲﾙ）健診", years(50), "60", "スマイル健診", ""},
+		{"98009001000021", "リコー_定期健診", years(34), "21", "定期健診(34歳以下)", ""},
+		{"98009001000021", "リコー_定期健診", years(35), "", "", ""},
+		{"98009001000001", "リコー_人間ドック", years(40), "", "", "コース登録の仕様"},
+		{"98009001000011", "リコー_総合Ｂ", years(35), "", "", "コース名"},
+		{"98009001009999", "リコー_総合Ａ", years(35), "", "", "コースコード"},
+		{"98009001000011", "リコー_総合Ａ", years(-1), "", "", "年齢が分からない"},
+		{"98009001000017", "リコー_基本(ｽﾏｲﾙ)健診", years(-1), "60", "スマイル健診", ""},
+	}
+	for _, tt := range tests {
+		cd, name, err := m.Classify(tt.cd, tt.name, tt.age, "2024/06/11")
+		if cd != tt.wantCd || name != tt.wantName {
+			t.Errorf("Classify(%s_%s, %d歳) = %q %q, want %q %q", tt.cd, tt.name, tt.age.Exam, cd, name, tt.wantCd, tt.wantName)
+		}
+		if (err != nil) != (tt.err != "") || err != nil && !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("Classify(%s_%s, %d歳) err %v, want %q", tt.cd, tt.name, tt.age.Exam, err, tt.err)
+		}
+	}
+}
+
+func TestLoadCourses(t *testing.T) {
+	// 追加したコース・適用期間・年齢基準はコースマスタの変更だけで使える
+
+	m, err := LoadCourses(strings.NewReader(`版,test
+コースコード,コース名,年齢条件,リコーコード,リコーコース名,適用開始,適用終了,年齢基準
+98009001000026,リコー_新コース,節目,61,新コース(節目),2025-04-01,,年度末
+98009001000026,リコー_新コース,,62,新コース,2025-04-01,,
+98009001000026,リコー_新コース,,63,旧コース,,2025/03/31,
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Version != "test" || len(m.Rules) != 3 || m.Rules[0].From != "2025/04/01" {
+		t.Fatalf("コースマスタ %+v", m)
+	}
+
+	// 適用期間は受診日で、節目は年度末の年齢で判定する
+	tests := []struct {
+		jday string
+		age  Age
+		want string
+	}{
+		{"2025/03/31", Age{Exam: 45, FiscalEnd: 45}, "63"},
+		{"2025/06/11", Age{Exam: 45, FiscalEnd: 46}, "62"},
+		{"2025/06/11", Age{Exam: 44, FiscalEnd: 45}, "61"},
+	}
+	for _, tt := range tests {
+		cd, _, err := m.Classify("98009001000026", "リコー_新コース", tt.age, tt.jday)
+		if err != nil || cd != tt.want {
+			t.Errorf("%s 受診日%d歳 年度末%d歳: %q %v, want %q", tt.jday, tt.age.Exam, tt.age.FiscalEnd, cd, err, tt.want)
+		}
+	}
+
+	bad := []string{
+		"98009001000026,リコー_新コース,40歳,61,新コース,,,",
+		"98009001000026,リコー_新コース,,61,新コース,,,誕生日",
+		"98009001000026,リコー_新コース,,61,,,,",
+		",リコー_新コース,,61,新コース,,,",
+	}
+	for _, line := range bad {
+		csv := "版,test\nコースコード,コース名,年齢条件,リコーコード,リコーコース名,適用開始,適用終了,年齢基準\n" + line + "\n"
+		if _, err := LoadCourses(strings.NewReader(csv)); err == nil || !strings.Contains(err.Error(), "3行目") {
+			t.Errorf("[%s] err %v", line, err)
+		}
+	}
+}
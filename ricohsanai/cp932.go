@@ -0,0 +1,261 @@
+package ricohsanai
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+)
+
+//go:embed master/chars.csv
+var defaultCharsCSV []byte
+
+// cp932 はNWの抽出データと提出データの文字コード(Windows-31J)
+// golang.org/x/text の ShiftJIS は Windows-31J の対応表（NEC特殊文字・NEC選定IBM拡張文字・IBM拡張文字を含む）
+var cp932 = japanese.ShiftJIS
+
+// cp932Fold はJIS(Shift_JIS)の対応表の文字を Windows-31J で同じコードになる文字にする
+// （Macやブラウザから貼り付けた「〜」「−」などがエラーにならないように）
+var cp932Fold = map[rune]rune{
+	'\u301C': '\uFF5E', // WAVE DASH -> FULLWIDTH TILDE
+	'\u2212': '\uFF0D', // MINUS SIGN -> FULLWIDTH HYPHEN-MINUS
+	'\u2016': '\u2225', // DOUBLE VERTICAL LINE -> PARALLEL TO
+	'\u2014': '\u2015', // EM DASH -> HORIZONTAL BAR
+	'\u00A2': '\uFFE0', // CENT SIGN -> FULLWIDTH CENT SIGN
+	'\u00A3': '\uFFE1', // POUND SIGN -> FULLWIDTH POUND SIGN
+	'\u00AC': '\uFFE2', // NOT SIGN -> FULLWIDTH NOT SIGN
+}
+
+// cp932Missing は Windows-31J で書き出せない文字の代わりに書き出す文字
+const cp932Missing = '〓'
+
+// cp932Size は Windows-31J で書き出せる文字(BMP)のバイト数（書き出せない文字は0）
+// 文字ごとにエンコーダを作らないように、最初に使う時に対応表から作る
+var (
+	cp932SizeOnce sync.Once
+	cp932Size     [0x10000]uint8
+)
+
+func initCp932Size() {
+	// 1バイト(半角カタカナ)と2バイトのコードをすべて読んで、読めた文字のバイト数を記録する
+
+	dec := cp932.NewDecoder()
+	set := func(code []byte) {
+		s, err := dec.Bytes(code)
+		r, n := utf8.DecodeRune(s)
+		if err == nil && n == len(s) && r != utf8.RuneError && r < 0x10000 && cp932Size[r] == 0 {
+			cp932Size[r] = uint8(len(code))
+		}
+	}
+	for b := 0xA1; b <= 0xDF; b++ {
+		set([]byte{byte(b)})
+	}
+	for lead := 0x81; lead <= 0xFC; lead++ {
+		for trail := 0x40; trail <= 0xFC; trail++ {
+			set([]byte{byte(lead), byte(trail)})
+		}
+	}
+}
+
+func cp932Rune(r rune) bool {
+	// Windows-31J で書き出せる文字か確認する
+
+	if r < utf8.RuneSelf {
+		return true
+	}
+	if r >= 0x10000 {
+		return false
+	}
+	cp932SizeOnce.Do(initCp932Size)
+
+	return cp932Size[r] != 0
+}
+
+func cp932Len(r rune) int {
+	// 1文字の CP932 のバイト数を返す（書き出せない文字は〓の2バイト）
+
+	if r < utf8.RuneSelf {
+		return 1
+	}
+	if !cp932Rune(r) {
+		return 2
+	}
+
+	return int(cp932Size[r])
+}
+
+func cp932Bytes(str string) int {
+	// 文字列の CP932 のバイト数を返す
+
+	n := 0
+	for _, r := range str {
+		n += cp932Len(r)
+	}
+
+	return n
+}
+
+func cp932Reader(r io.Reader) io.Reader {
+	// Windows-31J の抽出データをUTF-8で読む
+
+	return transform.NewReader(r, cp932.NewDecoder())
+}
+
+func cp932Writer(w io.Writer) *transform.Writer {
+	// UTF-8の文字列を Windows-31J で書き出す
+	// 書き出せない文字は〓にする（提出データは変換時に確認・置き換え済み）
+
+	fold := runes.Map(func(r rune) rune {
+		if f, ok := cp932Fold[r]; ok {
+			return f
+		}
+		if !cp932Rune(r) {
+			return cp932Missing
+		}
+		return r
+	})
+
+	return transform.NewWriter(w, transform.Chain(fold, cp932.NewEncoder()))
+}
+
+// CharMaster は提出データに書き出す前に置き換える文字を決める置換文字マスタ
+type CharMaster struct {
+	Version string
+	Subst   map[rune]string // 文字ごとの置換文字
+}
+
+func DefaultChars() *CharMaster {
+	// 内蔵の置換文字マスタを返す
+
+	m, err := LoadChars(bytes.NewReader(defaultCharsCSV))
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+func DefaultCharsCSV() []byte {
+	// 内蔵の置換文字マスタのファイル内容を返す
+
+	return defaultCharsCSV
+}
+
+func LoadChars(r io.Reader) (*CharMaster, error) {
+	// 置換文字マスタを読み込む
+
+	const name = "置換文字マスタ"
+	t, err := readTable(r, name)
+	if err != nil {
+		return nil, err
+	}
+
+	pos, err := t.columns(name, "文字", "置換文字")
+	if err != nil {
+		return nil, err
+	}
+
+	m := &CharMaster{Version: t.version, Subst: map[rune]string{}}
+	for i, items := range t.rows {
+		from, to := items[pos[0]], items[pos[1]]
+		if utf8.RuneCountInString(from) != 1 {
+			return nil, fmt.Errorf("%s %d行目: 文字は１文字で指定してください[%s]", name, t.lines[i], from)
+		}
+		for _, r := range to {
+			if !cp932Rune(r) {
+				return nil, fmt.Errorf("%s %d行目: 置換文字[%s]はCP932で書き出せません", name, t.lines[i], to)
+			}
+		}
+
+		r, _ := utf8.DecodeRuneInString(from)
+		if _, ok := m.Subst[r]; ok {
+			return nil, fmt.Errorf("%s %d行目: 文字[%s]が重複しています", name, t.lines[i], from)
+		}
+		m.Subst[r] = to
+	}
+
+	return m, nil
+}
+
+func (rec *record) cp932(str string) string {
+	// 提出データに書き出せる文字にする
+	// 置換文字マスタの文字は置き換えて警告、Windows-31J で書き出せない文字は〓にしてエラーにする
+
+	if isASCII(str) {
+		return str
+	}
+
+	var b strings.Builder
+	var subst, missing []string
+	var first rune // 最初に置き換えた文字（問題の項目名・値に使う）
+	for _, r := range str {
+		if f, ok := cp932Fold[r]; ok {
+			r = f
+		}
+		if to, ok := rec.c.Chars.Subst[r]; ok {
+			b.WriteString(to)
+			if first == 0 {
+				first = r
+			}
+			subst = append(subst, fmt.Sprintf("%c->%s", r, to))
+			continue
+		}
+		if !cp932Rune(r) {
+			b.WriteRune(cp932Missing)
+			if first == 0 {
+				first = r
+			}
+			if r == utf8.RuneError {
+				missing = append(missing, "(読めない文字)")
+			} else {
+				missing = append(missing, fmt.Sprintf("%c(%U)", r, r))
+			}
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	src, value := rec.srcOf(first)
+	if len(subst) > 0 {
+		rec.check(&issueError{sev: Warning, code: CodeChar, src: src, value: value, err: fmt.Errorf("文字を置き換えました[%s]", strings.Join(subst, " "))})
+	}
+	if len(missing) > 0 {
+		rec.check(issueOf(CodeChar, src, value, fmt.Errorf("CP932で書き出せない文字を〓にしました[%s]", strings.Join(missing, " "))))
+	}
+
+	return b.String()
+}
+
+func (rec *record) srcOf(r rune) (string, string) {
+	// 変換中の列で文字 r を含む抽出データの項目名と値を返す
+
+	if rec.col == nil {
+		return "", ""
+	}
+	for i, name := range rec.col.src {
+		if strings.ContainsRune(rec.v[i], r) {
+			return name, rec.v[i]
+		}
+	}
+
+	return "", ""
+}
+
+func isASCII(str string) bool {
+	// ASCII文字だけか確認する
+
+	for i := 0; i < len(str); i++ {
+		if str[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,24 @@
+package ricohsanai
+
+import "testing"
+
+func TestCp932Len(t *testing.T) {
+	// 対応表から作ったバイト数がエンコーダの結果と同じか確認する
+
+	for r := rune(0); r < 0x10000; r++ {
+		want := 2
+		b, err := cp932.NewEncoder().String(string(r))
+		if err == nil {
+			want = len(b)
+		}
+		if got := cp932Len(r); got != want {
+			t.Errorf("cp932Len(%U) = %d, want %d", r, got, want)
+		}
+		if got := cp932Rune(r); got != (err == nil) {
+			t.Errorf("cp932Rune(%U) = %v, want %v", r, got, err == nil)
+		}
+	}
+	if cp932Rune(0x20000) || cp932Len(0x20000) != 2 {
+		t.Errorf("BMP外の文字 %U", rune(0x20000))
+	}
+}
@@ -0,0 +1,259 @@
+package ricohsanai
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/width"
+)
+
+// era は和暦の元号
+type era struct {
+	letter string    // 略号
+	name   string    // 元号
+	start  time.Time // 元年の最初の日
+}
+
+// 元号は新しい順に並べる
+var eras = []era{
+	{"R", "令和", time.Date(2019, 5, 1, 0, 0, 0, 0, time.Local)},
+	{"H", "平成", time.Date(1989, 1, 8, 0, 0, 0, 0, time.Local)},
+	{"S", "昭和", time.Date(1926, 12, 25, 0, 0, 0, 0, time.Local)},
+	{"T", "大正", time.Date(1912, 7, 30, 0, 0, 0, 0, time.Local)},
+	{"M", "明治", time.Date(1868, 1, 25, 0, 0, 0, 0, time.Local)},
+}
+
+// 生年月日から受診日までの最大の年数
+const maxAge = 120
+
+func parseDate(str string) (time.Time, error) {
+	// 日付を読む
+	// 和暦は「S45.01.02」「昭和45年1月2日」「平成元年」、西暦は「2024/06/11」「2024-6-11」「20240611」「2024年6月11日」
+	// 全角の数字・記号も読み、元号の期間外の日付やカレンダーに無い日付はエラーにする
+
+	s := strings.TrimSpace(width.Fold.String(str))
+	if s == "" {
+		return time.Time{}, fmt.Errorf("日付が空欄です")
+	}
+
+	// 元号
+	e, s := cutEra(s)
+	s = strings.Replace(strings.TrimSpace(s), "元", "1", 1)
+
+	// 年月日の数字
+	for _, r := range s {
+		if !('0' <= r && r <= '9') && !strings.ContainsRune("年月日./- ", r) {
+			return time.Time{}, fmt.Errorf("日付の形式が違います[%s]", str)
+		}
+	}
+	nums := strings.FieldsFunc(s, func(r rune) bool { return r < '0' || '9' < r })
+	if len(nums) == 1 {
+		// 区切りの無い日付（20240611 450102）
+		n := nums[0]
+		switch {
+		case e == nil && len(n) == 8:
+			nums = []string{n[0:4], n[4:6], n[6:8]}
+		case e != nil && len(n) == 6:
+			nums = []string{n[0:2], n[2:4], n[4:6]}
+		}
+	}
+	if len(nums) != 3 {
+		return time.Time{}, fmt.Errorf("日付の形式が違います[%s]", str)
+	}
+
+	var ymd [3]int
+	for i, n := range nums {
+		ymd[i], _ = strconv.Atoi(n)
+	}
+	year, month, day := ymd[0], ymd[1], ymd[2]
+	if e != nil {
+		if year < 1 || year > 99 {
+			return time.Time{}, fmt.Errorf("%sの年が違います[%s]", e.name, str)
+		}
+		year += e.start.Year() - 1
+	} else if len(nums[0]) != 4 {
+		return time.Time{}, fmt.Errorf("西暦の年は4桁で入力してください[%s]", str)
+	}
+
+	// カレンダーに無い日付（2月30日など）は time.Date で翌月になる
+	t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.Local)
+	if month < 1 || month > 12 || t.Day() != day {
+		return time.Time{}, fmt.Errorf("存在しない日付です[%s]", str)
+	}
+
+	// 元号の期間（平成31年5月1日は令和、令和元年4月30日は平成）
+	if e != nil {
+		if t.Before(e.start) {
+			return time.Time{}, fmt.Errorf("%sより前の日付です[%s]", e.name, str)
+		}
+		for i := range eras {
+			if &eras[i] == e {
+				break
+			}
+			if !t.Before(eras[i].start) {
+				return time.Time{}, fmt.Errorf("%s%d年%d月%d日は%sです[%s]", e.name, ymd[0], month, day, eras[i].name, str)
+			}
+		}
+	}
+
+	return t, nil
+}
+
+func cutEra(s string) (*era, string) {
+	// 先頭の元号(「昭和」「S」など)を１つだけ取り除き、元号と残りの文字列を返す（元号が無い時はnil）
+
+	for i := range eras {
+		if strings.HasPrefix(s, eras[i].name) {
+			return &eras[i], s[len(eras[i].name):]
+		}
+		if strings.HasPrefix(strings.ToUpper(s), eras[i].letter) {
+			return &eras[i], s[len(eras[i].letter):]
+		}
+	}
+
+	return nil, s
+}
+
+func normDay(str string) string {
+	// 日付を「yyyy/mm/dd」にする（読めない時はそのまま返す）
+
+	t, err := parseDate(str)
+	if err != nil {
+		return str
+	}
+
+	return t.Format("2006/01/02")
+}
+
+func (rec *record) asOf() time.Time {
+	// 変換の基準日を返す
+
+	now := rec.c.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+}
+
+func birthDay(rec *record, v []string) (string, error) {
+	// 生年月日を「yyyy/mm/dd」にする
+	// 受診日より後の日付や、受診日に120歳を超える日付はエラーにする
+
+	if v[0] == "" {
+		return "", nil
+	}
+
+	birth, err := parseDate(v[0])
+	if err != nil {
+		return v[0], issue(CodeDate, fmt.Errorf("生年月日変換エラー: %s", err))
+	}
+	day := birth.Format("2006/01/02")
+
+	base, err := parseDate(rec.get("受診日"))
+	if err != nil {
+		base = rec.asOf()
+	}
+	switch {
+	case birth.After(base):
+		return day, issue(CodeDate, fmt.Errorf("生年月日[%s]が受診日より後の日付です", day))
+	case !birth.AddDate(maxAge+1, 0, 0).After(base):
+		return day, issue(CodeDate, fmt.Errorf("生年月日[%s]が受診日に%d歳を超えています", day, maxAge))
+	}
+
+	return day, nil
+}
+
+func jusinDay(rec *record, v []string) (string, error) {
+	// 受診日を「yyyy/mm/dd」にする
+	// 変換の基準日より後の日付はエラーにする
+
+	if v[0] == "" {
+		return "", nil
+	}
+
+	jday, err := parseDate(v[0])
+	if err != nil {
+		return v[0], issue(CodeDate, fmt.Errorf("受診日変換エラー: %s", err))
+	}
+	day := jday.Format("2006/01/02")
+
+	if jday.After(rec.asOf()) {
+		return day, issue(CodeDate, fmt.Errorf("受診日[%s]が未来の日付です", day))
+	}
+
+	return day, nil
+}
+
+func kigenDay(rec *record, v []string) (string, error) {
+	// 受診券有効期限を「yyyy/mm/dd」にする
+
+	if v[0] == "" {
+		return "", nil
+	}
+
+	kigen, err := parseDate(v[0])
+	if err != nil {
+		return v[0], issue(CodeDate, fmt.Errorf("受診券有効期限変換エラー: %s", err))
+	}
+
+	return kigen.Format("2006/01/02"), nil
+}
+
+func ageAt(birth time.Time, day time.Time) int {
+	// day の時点の満年齢を返す（2月29日生まれはうるう年以外は3月1日に歳をとる）
+
+	age := day.Year() - birth.Year()
+	if birth.AddDate(age, 0, 0).After(day) {
+		age--
+	}
+
+	return age
+}
+
+func fiscalEnd(day time.Time) time.Time {
+	// day を含む年度の末日(3月31日)を返す
+
+	year := day.Year()
+	if day.Month() >= time.April {
+		year++
+	}
+
+	return time.Date(year, time.March, 31, 0, 0, 0, 0, time.Local)
+}
+
+func (rec *record) ages() Age {
+	// 年齢基準ごとの年齢を返す
+	// 生年月日と受診日から計算し、どちらかが読めない時は抽出データの年齢を使う
+
+	if rec.age != nil {
+		return *rec.age
+	}
+
+	age := Age{Exam: -1, FiscalEnd: -1}
+	birth, err1 := parseDate(rec.get("生年月日"))
+	jday, err2 := parseDate(rec.get("受診日"))
+	if err1 == nil && err2 == nil && !birth.After(jday) {
+		age = Age{Exam: ageAt(birth, jday), FiscalEnd: ageAt(birth, fiscalEnd(jday)), Calc: true}
+	} else if n, err := strconv.Atoi(rec.get("年齢")); err == nil {
+		age = Age{Exam: n, FiscalEnd: n}
+	}
+	rec.age = &age
+
+	return age
+}
+
+func (rec *record) checkAge() {
+	// 抽出データの年齢を生年月日・受診日から計算した年齢と比べる
+	// 受診日の年齢か年度末の年齢のどちらかと同じなら問題にしない
+
+	str := rec.get("年齢")
+	age := rec.ages()
+	n, err := strconv.Atoi(str)
+	switch {
+	case err != nil && age.Calc:
+		rec.check(&issueError{sev: Warning, code: CodeAge, src: "年齢", value: str, err: fmt.Errorf("年齢エラー[%s]。生年月日・受診日から計算した年齢(%d歳)を使います", str, age.Exam)})
+	case err != nil:
+		rec.check(issueOf(CodeAge, "年齢", str, fmt.Errorf("年齢エラー[%s]。生年月日・受診日も読めないためコースを決められません", str)))
+	case age.Calc && n != age.Exam && n != age.FiscalEnd:
+		rec.check(issueOf(CodeAge, "年齢", str, fmt.Errorf("年齢[%d]が生年月日・受診日から計算した年齢と違います(受診日%d歳 年度末%d歳)。計算した年齢でコースを決めます", n, age.Exam, age.FiscalEnd)))
+	}
+}
@@ -0,0 +1,191 @@
+package ricohsanai
+
+import (
+	"testing"
+	"time"
+)
+
+func day(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+}
+
+func TestParseDate(t *testing.T) {
+	// 西暦・和暦の書き方と、元号の境目・元年を確認する
+
+	ok := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2024/06/11", day(2024, 6, 11)},
+		{"2024-6-11", day(2024, 6, 11)},
+		{"20240611", day(2024, 6, 11)},
+		{"2024年6月11日", day(2024, 6, 11)},
+		{"２０２４／０６／１１", day(2024, 6, 11)},
+		{" 2024/06/11 ", day(2024, 6, 11)},
+		{"2024/02/29", day(2024, 2, 29)},
+		{"S45.01.02", day(1970, 1, 2)},
+		{"s450102", day(1970, 1, 2)},
+		{"昭和45年1月2日", day(1970, 1, 2)},
+		{"Ｓ４５．０１．０２", day(1970, 1, 2)},
+		{"T15.12.24", day(1926, 12, 24)},
+		{"S01.12.25", day(1926, 12, 25)},
+		{"昭和元年12月25日", day(1926, 12, 25)},
+		{"S64.01.07", day(1989, 1, 7)},
+		{"H01.01.08", day(1989, 1, 8)},
+		{"平成元年1月8日", day(1989, 1, 8)},
+		{"H31.04.30", day(2019, 4, 30)},
+		{"R01.05.01", day(2019, 5, 1)},
+		{"令和元年5月1日", day(2019, 5, 1)},
+		{"R06.06.11", day(2024, 6, 11)},
+	}
+	for _, tt := range ok {
+		got, err := parseDate(tt.in)
+		if err != nil {
+			t.Errorf("parseDate(%q) エラー: %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseDate(%q) = %s, want %s", tt.in, got.Format("2006/01/02"), tt.want.Format("2006/01/02"))
+		}
+	}
+
+	ng := []string{
+		"",
+		"abc",
+		"24/06/11",     // 西暦は4桁
+		"2023/02/29",   // うるう年ではない
+		"2024/13/01",   // 13月
+		"S64.01.08",    // 平成の日付
+		"H01.01.07",    // 平成より前
+		"H31.05.01",    // 令和の日付
+		"R01.04.30",    // 令和より前
+		"令和元年4月30日",    // 令和より前
+		"S00.01.01",    // 0年
+		"M01.01.24",    // 明治より前
+		"2024/06/11/1", // 数字が多い
+		"HS45.01.02",   // 元号が２つ
+		"平成S45.01.02",  // 元号が２つ
+		"RR01.05.01",   // 元号が２つ
+	}
+	for _, in := range ng {
+		if got, err := parseDate(in); err == nil {
+			t.Errorf("parseDate(%q) = %s, エラーになりません", in, got.Format("2006/01/02"))
+		}
+	}
+}
+
+func TestKigenDay(t *testing.T) {
+	// 受診券有効期限は日付にし、読めない時は DATE のエラーにする
+
+	header, rows := readTestRows(t)
+	c := testConverter()
+	if _, err := c.SetHeader(header); err != nil {
+		t.Fatal(err)
+	}
+	col := colIndex("受診券有効期限")
+
+	tests := []struct {
+		in, want string
+		bad      bool
+	}{
+		{"", "", false},
+		{"R07.03.31", "2025/03/31", false},
+		{"2025-3-31", "2025/03/31", false},
+		{"2025/02/30", "2025/02/30", true},
+	}
+	for _, tt := range tests {
+		got, issues := c.ConvertRecord(setTestValue(header, rows[0], "受診券有効期限", tt.in))
+		if got[col] != tt.want {
+			t.Errorf("受診券有効期限[%s] = %q, want %q", tt.in, got[col], tt.want)
+		}
+		bad := false
+		for _, is := range issues {
+			if is.Code == CodeDate && is.Src == "受診券有効期限" {
+				bad = true
+			}
+		}
+		if bad != tt.bad {
+			t.Errorf("受診券有効期限[%s] DATE %v, want %v", tt.in, bad, tt.bad)
+		}
+	}
+}
+func TestAgeAt(t *testing.T) {
+	// 満年齢（2月29日生まれはうるう年以外は3月1日に歳をとる）
+
+	tests := []struct {
+		birth, day time.Time
+		want       int
+	}{
+		{day(1980, 1, 1), day(2024, 6, 11), 44},
+		{day(1980, 1, 1), day(2023, 12, 31), 43},
+		{day(1980, 6, 11), day(2024, 6, 10), 43},
+		{day(1980, 6, 11), day(2024, 6, 11), 44},
+		{day(2000, 2, 29), day(2001, 2, 28), 0},
+		{day(2000, 2, 29), day(2001, 3, 1), 1},
+		{day(2000, 2, 29), day(2004, 2, 28), 3},
+		{day(2000, 2, 29), day(2004, 2, 29), 4},
+		{day(2004, 2, 29), fiscalEnd(day(2024, 6, 11)), 21},
+		{day(2004, 2, 29), fiscalEnd(day(2024, 2, 28)), 20},
+	}
+	for _, tt := range tests {
+		if got := ageAt(tt.birth, tt.day); got != tt.want {
+			t.Errorf("ageAt(%s, %s) = %d, want %d", tt.birth.Format("2006/01/02"), tt.day.Format("2006/01/02"), got, tt.want)
+		}
+	}
+}
+
+func TestFiscalEnd(t *testing.T) {
+	// 年度末(3月31日)
+
+	tests := []struct {
+		day, want time.Time
+	}{
+		{day(2024, 3, 31), day(2024, 3, 31)},
+		{day(2024, 4, 1), day(2025, 3, 31)},
+		{day(2024, 2, 29), day(2024, 3, 31)},
+		{day(2024, 12, 31), day(2025, 3, 31)},
+		{day(2025, 1, 1), day(2025, 3, 31)},
+	}
+	for _, tt := range tests {
+		if got := fiscalEnd(tt.day); !got.Equal(tt.want) {
+			t.Errorf("fiscalEnd(%s) = %s, want %s", tt.day.Format("2006/01/02"), got.Format("2006/01/02"), tt.want.Format("2006/01/02"))
+		}
+	}
+}
+
+func TestCheckAge(t *testing.T) {
+	// 抽出データの年齢が受診日・年度末のどちらの年齢とも違う時は AGE にする
+	// （1980/01/01生まれ 2024/06/11受診 → 受診日44歳 年度末45歳）
+
+	header, rows := readTestRows(t)
+	c := testConverter()
+	if _, err := c.SetHeader(header); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		age string
+		sev Severity
+		n   int
+	}{
+		{"44", 0, 0},
+		{"45", 0, 0},
+		{"50", Error, 1},
+		{"", Warning, 1},
+	}
+	for _, tt := range tests {
+		_, issues := c.ConvertRecord(setTestValue(header, rows[0], "年齢", tt.age))
+		n := 0
+		for _, is := range issues {
+			if is.Code == CodeAge {
+				n++
+				if is.Severity != tt.sev {
+					t.Errorf("年齢[%s] %v, want %v", tt.age, is.Severity, tt.sev)
+				}
+			}
+		}
+		if n != tt.n {
+			t.Errorf("年齢[%s] AGE %d件, want %d件", tt.age, n, tt.n)
+		}
+	}
+}
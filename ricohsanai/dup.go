@@ -0,0 +1,168 @@
+package ricohsanai
+
+import (
+	"fmt"
+)
+
+// DupPolicy は重複したデータのどれを残すか
+type DupPolicy int
+
+const (
+	KeepFirst  DupPolicy = iota // 先のデータを残す
+	KeepLatest                  // 後のデータを残す（抽出し直したデータを後に追加した時）
+	DupAbort                    // 変換を中止する
+)
+
+func ParseDupPolicy(str string) (DupPolicy, error) {
+	// -dup の指定を DupPolicy にする
+
+	switch str {
+	case "first":
+		return KeepFirst, nil
+	case "latest":
+		return KeepLatest, nil
+	case "abort":
+		return DupAbort, nil
+	default:
+		return KeepFirst, fmt.Errorf("重複データの扱い[%s]は first latest abort のどれかにしてください", str)
+	}
+}
+
+func (p DupPolicy) String() string {
+	switch p {
+	case KeepFirst:
+		return "first(先のデータを残す)"
+	case KeepLatest:
+		return "latest(後のデータを残す)"
+	case DupAbort:
+		return "abort(中止する)"
+	default:
+		return fmt.Sprintf("DupPolicy(%d)", int(p))
+	}
+}
+
+// dupKey は重複を確認する項目
+type dupKey struct {
+	kind  string
+	value string
+}
+
+func dupKeys(rec *record) []dupKey {
+	// 同じ受診とみなす項目を返す
+	// 受診番号が同じ、または個人ID・生年月日・受診日が同じなら同じ受診
+
+	var keys []dupKey
+	if rec.jusinNo != "" {
+		keys = append(keys, dupKey{"受診番号", rec.jusinNo})
+	}
+	if id := rec.get("社員No"); id != "" {
+		keys = append(keys, dupKey{"個人ID", id + "\t" + normDay(rec.get("生年月日")) + "\t" + normDay(rec.get("受診日"))})
+	}
+
+	return keys
+}
+
+func (c *Converter) dedup(rows []converted, inputs []Input) ([]bool, [][]Issue) {
+	// 重複したデータを探して、残すデータと重複の問題を返す
+
+	keep := make([]bool, len(rows))
+	dups := make([][]Issue, len(rows))
+
+	order := make([]int, len(rows))
+	for i := range order {
+		order[i] = i
+		if c.Dup == KeepLatest {
+			order[i] = len(rows) - 1 - i
+		}
+	}
+
+	seen := map[dupKey]int{}
+	for _, i := range order {
+		rec := rows[i].rec
+		j, key, found := -1, dupKey{}, false
+		for _, k := range dupKeys(rec) {
+			if j, found = seen[k]; found {
+				key = k
+				break
+			}
+		}
+
+		if !found {
+			keep[i] = true
+			for _, k := range dupKeys(rec) {
+				seen[k] = i
+			}
+			continue
+		}
+
+		msg := ""
+		if key.kind == "受診番号" {
+			msg = fmt.Sprintf("受診番号[%s]が重複しています(%sと同じ)", rec.jusinNo, where(rows[j], inputs))
+		} else {
+			msg = fmt.Sprintf("個人ID[%s]・生年月日・受診日が同じデータがあります(%s 受診番号[%s])", rec.get("社員No"), where(rows[j], inputs), rows[j].rec.jusinNo)
+		}
+
+		sev := Warning
+		switch c.Dup {
+		case KeepFirst:
+			msg += "。先のデータを残してこのデータは除外しました"
+		case KeepLatest:
+			msg += "。後のデータを残してこのデータは除外しました"
+		case DupAbort:
+			sev = Error
+		}
+		dups[i] = append(dups[i], rec.dupIssue(sev, CodeDuplicate, msg))
+	}
+
+	// 氏名・カナ氏名・生年月日が同じで個人IDが違うデータは同じ人の可能性がある
+	type person struct {
+		i  int
+		id string
+	}
+	people := map[string]person{}
+	for i := range rows {
+		if !keep[i] {
+			continue
+		}
+		rec := rows[i].rec
+		key := rec.name + "\t" + rec.get("カナ氏名") + "\t" + normDay(rec.get("生年月日"))
+		if rec.name == "" || rec.get("生年月日") == "" {
+			continue
+		}
+		id := rec.get("社員No")
+		if p, ok := people[key]; !ok {
+			people[key] = person{i, id}
+		} else if p.id != id {
+			msg := fmt.Sprintf("氏名・カナ氏名・生年月日が同じで個人IDが違うデータがあります(%s 受診番号[%s] 個人ID[%s])", where(rows[p.i], inputs), rows[p.i].rec.jusinNo, p.id)
+			dups[i] = append(dups[i], rec.dupIssue(Warning, CodeSimilar, msg))
+		}
+	}
+
+	return keep, dups
+}
+
+func where(cv converted, inputs []Input) string {
+	// ログ用に「ファイル名 行番号」を返す
+
+	if name := inputs[cv.file].Name; name != "" {
+		return fmt.Sprintf("%s %d行目", name, cv.line)
+	}
+
+	return fmt.Sprintf("%d行目", cv.line)
+}
+
+func (rec *record) dupIssue(sev Severity, code string, msg string) Issue {
+	// 重複を問題として返す
+
+	return Issue{
+		Severity: sev,
+		Code:     code,
+		JusinNo:  rec.jusinNo,
+		Name:     rec.name,
+		JDay:     rec.get("受診日"),
+		Course:   rec.get("コース名"),
+		Src:      "受診番号",
+		Value:    rec.jusinNo,
+		Message:  msg,
+	}
+}
@@ -0,0 +1,105 @@
+package ricohsanai
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestDedup(t *testing.T) {
+	// 重複したデータは -dup の指定で先・後のデータを残すか中止する
+
+	header, rows := readTestRows(t)
+	again := setTestValue(header, rows[0], "受診番号", "100009") // 個人ID・生年月日・受診日が同じ
+	again = setTestValue(header, again, "漢字氏名", "山田　太郎9")
+	nameCol := colIndex("漢字氏名")
+
+	tests := []struct {
+		policy DupPolicy
+		want   []string
+	}{
+		{KeepFirst, []string{"山田　太郎0", "山田　太郎1", "山田　太郎2"}},
+		{KeepLatest, []string{"山田　太郎1", "山田　太郎2", "山田　太郎9"}},
+	}
+	for _, tt := range tests {
+		c := testConverter()
+		c.Dup = tt.policy
+		var out bytes.Buffer
+		res, err := c.ConvertFiles([]Input{testInput(t, "a.txt", header, rows...), testInput(t, "b.txt", header, again)}, &out)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.policy, err)
+		}
+		var got []string
+		for _, row := range readTestCSV(t, out.Bytes()) {
+			got = append(got, row[nameCol])
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: 書き出した行 %v, want %v", tt.policy, got, tt.want)
+		}
+		if n := countIssues(res.Issues, CodeDuplicate); n != 1 {
+			t.Errorf("%s: DUP %d件, want 1件", tt.policy, n)
+		}
+	}
+
+	// 中止する時は何も書き出さない
+	c := testConverter()
+	c.Dup = DupAbort
+	var out bytes.Buffer
+	res, err := c.ConvertFiles([]Input{testInput(t, "a.txt", header, rows...), testInput(t, "b.txt", header, again)}, &out)
+	if err == nil {
+		t.Fatal("abort: 重複があってもエラーになりません")
+	}
+	if out.Len() != 0 || res.Count != 0 {
+		t.Errorf("abort: %d件書き出しました", res.Count)
+	}
+	if n := countIssues(res.Issues, CodeDuplicate); n != 1 {
+		t.Errorf("abort: DUP %d件, want 1件", n)
+	}
+}
+
+func TestDedupAbortKeepsReadIssues(t *testing.T) {
+	// 重複が無い時も、読み込みでの問題(タイトル行の警告)は残す
+
+	header, rows := readTestRows(t)
+	swapped := append([]string(nil), header...)
+	i, j := swapped[3], swapped[5] // 所属名1・所属名2の位置を入れ替える
+	for k, h := range swapped {
+		switch h {
+		case i:
+			swapped[k] = j
+		case j:
+			swapped[k] = i
+		}
+	}
+	moved := make([][]string, len(rows))
+	for n, row := range rows {
+		moved[n] = append([]string(nil), row...)
+		moved[n][3], moved[n][5] = row[5], row[3]
+	}
+
+	for _, policy := range []DupPolicy{KeepFirst, DupAbort} {
+		c := testConverter()
+		c.Dup = policy
+		var out bytes.Buffer
+		res, err := c.ConvertFiles([]Input{testInput(t, "a.txt", swapped, moved...)}, &out)
+		if err != nil {
+			t.Fatalf("%s: %v", policy, err)
+		}
+		if n := countIssues(res.Issues, CodeLayout); n != 2 {
+			t.Errorf("%s: LAYOUT %d件, want 2件", policy, n)
+		}
+	}
+}
+
+func countIssues(issues []Issue, code string) int {
+	// code の問題の件数を返す
+
+	n := 0
+	for _, is := range issues {
+		if is.Code == code {
+			n++
+		}
+	}
+
+	return n
+}
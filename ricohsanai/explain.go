@@ -0,0 +1,200 @@
+package ricohsanai
+
+import (
+	"fmt"
+)
+
+// コースマスタの行を確認した結果
+const (
+	stepMatch   = "採用"
+	stepName    = "コース名が違う"
+	stepPeriod  = "適用期間外"
+	stepAge     = "年齢条件に合わない"
+	stepUnknown = "年齢が分からない"
+	stepSkip    = "確認しない(前の行で決定)"
+)
+
+// CourseStep はコースを決める時に確認したコースマスタの１行と結果
+type CourseStep struct {
+	Rule   CourseRule
+	Age    int    // 年齢基準の年齢(-1は分からない)
+	Result string // 確認した結果
+}
+
+func (st CourseStep) String() string {
+	// 「n行目 コース名 年齢条件 -> リコーコード: 結果」の形式で返す
+
+	rule := st.Rule
+	cond := rule.Age
+	if cond == "" {
+		cond = "全年齢"
+	}
+	basis := rule.AgeBasis
+	if basis == "" {
+		basis = basisExam
+	}
+	ricoh := rule.RicohCd + " " + rule.RicohName
+	if rule.RicohCd == "" {
+		ricoh = "(空欄)"
+	}
+	str := fmt.Sprintf("%d行目 %s 年齢条件[%s](%s)", rule.Line, rule.Name, cond, basis)
+	if rule.From != "" || rule.To != "" {
+		str += fmt.Sprintf(" 適用[%s～%s]", rule.From, rule.To)
+	}
+	str += fmt.Sprintf(" -> %s: %s", ricoh, st.Result)
+	switch st.Result {
+	case stepMatch, stepAge:
+		str += fmt.Sprintf("(%s%d歳)", basis, st.Age)
+	}
+
+	return str
+}
+
+func (m *CourseMaster) Explain(cd string, name string, age Age, jday string) []CourseStep {
+	// コースコードが同じコースマスタの行を上から確認し、行ごとの結果を返す
+
+	var steps []CourseStep
+	done := false
+	for _, rule := range m.Rules {
+		if rule.Code != cd {
+			continue
+		}
+
+		st := CourseStep{Rule: rule, Age: age.of(rule.AgeBasis)}
+		switch {
+		case done:
+			st.Result = stepSkip
+		case rule.Name != name:
+			st.Result = stepName
+		case !rule.valid(jday):
+			st.Result = stepPeriod
+		case rule.Age != "" && st.Age < 0:
+			st.Result = stepUnknown
+			done = true
+		default:
+			if ok, _ := ageMatch(rule.Age, st.Age); ok {
+				st.Result = stepMatch
+				done = true
+			} else {
+				st.Result = stepAge
+			}
+		}
+		steps = append(steps, st)
+	}
+
+	return steps
+}
+
+// CourseRecord は抽出データ１行分のコースの決め方
+type CourseRecord struct {
+	File     string // 抽出データのファイル名
+	Line     int    // 抽出データの行番号
+	JusinNo  string
+	Name     string
+	JDay     string
+	Code     string // NWのコースコード
+	Course   string // NWのコース名
+	Age      Age
+	RicohCd  string // 変換したリコーのコースコード
+	RicohNm  string // 変換したリコーのコース名
+	Steps    []CourseStep
+	ErrorMsg string // コースを決められなかった理由
+}
+
+func (c *Converter) ExplainCourses(inputs []Input) ([]CourseRecord, error) {
+	// 抽出データの全レコードについて、コースの決め方を返す
+
+	res := &Result{}
+
+	var list []CourseRecord
+	for i, in := range inputs {
+		res.Files = append(res.Files, FileCount{Name: in.Name})
+		rows, err := c.readFile(in, i, res)
+		if err != nil {
+			if in.Name != "" {
+				err = fmt.Errorf("%s: %s", in.Name, err)
+			}
+			return list, err
+		}
+
+		for _, row := range rows {
+			rec := row.rec
+			cr := CourseRecord{
+				File:    in.Name,
+				Line:    row.line,
+				JusinNo: rec.jusinNo,
+				Name:    rec.name,
+				JDay:    rec.get("受診日"),
+				Code:    rec.get("コースコード"),
+				Course:  rec.get("コース名"),
+				Age:     rec.ages(),
+			}
+			cr.Steps = c.Courses.Explain(cr.Code, cr.Course, cr.Age, cr.JDay)
+			var err error
+			cr.RicohCd, cr.RicohNm, err = c.Courses.Classify(cr.Code, cr.Course, cr.Age, cr.JDay)
+			if err != nil {
+				cr.ErrorMsg = err.Error()
+			}
+			list = append(list, cr)
+		}
+	}
+
+	return list, nil
+}
+
+func (cr CourseRecord) Reason() string {
+	// リコーのコースコードが空欄になる理由を返す
+
+	if cr.ErrorMsg != "" {
+		return cr.ErrorMsg
+	}
+	for _, st := range cr.Steps {
+		if st.Result == stepMatch && st.Rule.RicohCd == "" {
+			cond := st.Rule.Age
+			if cond == "" {
+				cond = "全年齢"
+			}
+			return fmt.Sprintf("コースマスタ%d行目(年齢条件[%s])に合い、リコーコードが空欄の行です", st.Rule.Line, cond)
+		}
+	}
+
+	return ""
+}
+
+func ExplainLines(cr CourseRecord) []string {
+	// １レコードのコースの決め方の説明を返す
+
+	lines := []string{
+		fmt.Sprintf("受診番号 %s　%s　受診日 %s", cr.JusinNo, cr.Name, cr.JDay),
+		fmt.Sprintf("　コース：%s_%s", cr.Code, cr.Course),
+	}
+	if cr.Age.Calc {
+		lines = append(lines, fmt.Sprintf("　年齢　：受診日%d歳 年度末%d歳(生年月日・受診日から計算)", cr.Age.Exam, cr.Age.FiscalEnd))
+	} else if cr.Age.Exam >= 0 {
+		lines = append(lines, fmt.Sprintf("　年齢　：%d歳(抽出データの年齢)", cr.Age.Exam))
+	} else {
+		lines = append(lines, "　年齢　：分からない")
+	}
+
+	return append(lines, StepLines(cr.Steps, cr.RicohCd, cr.RicohNm, cr.Reason())...)
+}
+
+func StepLines(steps []CourseStep, cd string, name string, reason string) []string {
+	// コースマスタの行ごとの結果と、決まったコースを返す
+
+	var lines []string
+	if len(steps) == 0 {
+		lines = append(lines, "　コースマスタにこのコースコードの行がありません")
+	}
+	for _, st := range steps {
+		lines = append(lines, "　"+st.String())
+	}
+
+	if cd != "" {
+		lines = append(lines, fmt.Sprintf("　→ リコーのコース：%s %s", cd, name))
+	} else {
+		lines = append(lines, "　→ リコーのコース：(空欄) "+reason)
+	}
+
+	return lines
+}
@@ -0,0 +1,103 @@
+package ricohsanai
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	// コースコードが同じ行ごとに、採用した行と採用しなかった理由を返す
+
+	m := DefaultCourses()
+	years := func(n int) Age { return Age{Exam: n, FiscalEnd: n} }
+	results := func(steps []CourseStep) []string {
+		var list []string
+		for _, st := range steps {
+			list = append(list, st.Result)
+		}
+		return list
+	}
+
+	tests := []struct {
+		cd, name string
+		age      Age
+		want     []string
+	}{
+		{"98009001000011", "リコー_総合Ａ", years(40), []string{stepAge, stepMatch}},
+		{"98009001000011", "リコー_総合Ａ", years(35), []string{stepMatch, stepSkip}},
+		{"98009001000011", "リコー_総合Ａ", years(-1), []string{stepUnknown, stepSkip}},
+		{"98009001000012", "リコー_総合Ｂ", years(40), []string{stepAge}},
+		{"98009001000017", "リコー_基本(ｽﾏｲﾙ）健診", years(50), []string{stepName, stepMatch}},
+		{"98009001000021", "リコー_定期健診", years(35), []string{stepAge, stepMatch}},
+		{"98009001009999", "リコー_総合Ａ", years(35), nil},
+	}
+	for _, tt := range tests {
+		if got := results(m.Explain(tt.cd, tt.name, tt.age, "2024/06/11")); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Explain(%s_%s, %d歳) = %v, want %v", tt.cd, tt.name, tt.age.Exam, got, tt.want)
+		}
+	}
+
+	// 適用期間外の行
+	period := &CourseMaster{Rules: []CourseRule{{Code: "1", Name: "A", RicohCd: "61", RicohName: "旧", To: "2024/03/31", Line: 3}, {Code: "1", Name: "A", RicohCd: "62", RicohName: "新", Line: 4}}}
+	if got := results(period.Explain("1", "A", years(40), "2024-04-01")); !reflect.DeepEqual(got, []string{stepPeriod, stepMatch}) {
+		t.Errorf("適用期間 %v", got)
+	}
+
+	steps := m.Explain("98009001000011", "リコー_総合Ａ", years(40), "2024/06/11")
+	want := []string{
+		"　14行目 リコー_総合Ａ 年齢条件[=35](受診日) -> 31 総合健診A(35歳): 年齢条件に合わない(受診日40歳)",
+		"　15行目 リコー_総合Ａ 年齢条件[節目](受診日) -> 32 総合健診A(節目年齢): 採用(受診日40歳)",
+		"　→ リコーのコース：32 総合健診A(節目年齢)",
+	}
+	if got := StepLines(steps, "32", "総合健診A(節目年齢)", ""); !reflect.DeepEqual(got, want) {
+		t.Errorf("StepLines\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if got := StepLines(nil, "", "", "理由"); !reflect.DeepEqual(got, []string{"　コースマスタにこのコースコードの行がありません", "　→ リコーのコース：(空欄) 理由"}) {
+		t.Errorf("行が無い時 %v", got)
+	}
+}
+
+func TestExplainCourses(t *testing.T) {
+	// 抽出データの全レコードのコースの決め方と、リコーのコースコードが空欄になる理由を返す
+
+	header, rows := readTestRows(t)
+	teiki := setTestValue(header, setTestValue(header, rows[2], "コースコード", "98009001000021"), "コース名", "リコー_定期健診")
+	teiki = setTestValue(header, setTestValue(header, teiki, "生年月日", "1989/01/01"), "年齢", "35")
+	teiki = setTestValue(header, teiki, "受診日", "2024-06-11")
+
+	c := testConverter()
+	list, err := c.ExplainCourses([]Input{testInput(t, "a.txt", header, rows[0], rows[1], teiki)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 3 {
+		t.Fatalf("レコード %d件, want 3件", len(list))
+	}
+
+	// 事業主Ａは34歳以下だけ
+	if cr := list[0]; cr.RicohCd != "" || !strings.Contains(cr.Reason(), "コース登録の仕様") || cr.Line != 2 || cr.File != "a.txt" {
+		t.Errorf("事業主Ａ 44歳: %+v %s", cr, cr.Reason())
+	}
+	if cr := list[1]; cr.RicohCd != "21" || cr.Reason() != "" {
+		t.Errorf("リコー定期: %+v", cr)
+	}
+
+	// 定期健診の35歳以上はエラーにならずに空欄
+	cr := list[2]
+	if cr.RicohCd != "" || cr.ErrorMsg != "" || !strings.Contains(cr.Reason(), "リコーコードが空欄の行") {
+		t.Errorf("定期健診 35歳: %+v %s", cr, cr.Reason())
+	}
+	lines := ExplainLines(cr)
+	want := []string{
+		"受診番号 100002　山田　太郎2　受診日 2024-06-11",
+		"　コース：98009001000021_リコー_定期健診",
+		"　年齢　：受診日35歳 年度末36歳(生年月日・受診日から計算)",
+	}
+	if len(lines) < len(want) || !reflect.DeepEqual(lines[:len(want)], want) {
+		t.Errorf("ExplainLines\n%s\nwant\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+	if last := lines[len(lines)-1]; !strings.HasPrefix(last, "　→ リコーのコース：(空欄) コースマスタ") {
+		t.Errorf("最後の行 %s", last)
+	}
+}
@@ -0,0 +1,172 @@
+package ricohsanai
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"strings"
+)
+
+//go:embed master/group.csv
+var defaultGroupCSV []byte
+
+// GroupRule は所属ルールマスタの１行
+type GroupRule struct {
+	Code     string            // 所属cd1(*は他の行に無い所属cd1)
+	Name     string            // 所属名
+	Required []string          // 必須チェックする項目
+	KojinId  string            // 個人IDの確認方法(なし・必須・先頭X)
+	Defaults map[string]string // 抽出データが空欄の時に使う値
+	Line     int               // 所属ルールマスタの行番号
+}
+
+// GroupMaster は所属cd1ごとのチェック内容を決める所属ルールマスタ
+type GroupMaster struct {
+	Version string
+	Rules   []GroupRule
+}
+
+func DefaultGroups() *GroupMaster {
+	// 内蔵の所属ルールマスタを返す
+
+	m, err := LoadGroups(bytes.NewReader(defaultGroupCSV))
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+func DefaultGroupsCSV() []byte {
+	// 内蔵の所属ルールマスタのファイル内容を返す
+
+	return defaultGroupCSV
+}
+
+func LoadGroups(r io.Reader) (*GroupMaster, error) {
+	// 所属ルールマスタを読み込む
+
+	const name = "所属ルールマスタ"
+	t, err := readTable(r, name)
+	if err != nil {
+		return nil, err
+	}
+
+	pos, err := t.columns(name, "所属cd1", "所属名", "必須項目", "個人ID", "既定値")
+	if err != nil {
+		return nil, err
+	}
+
+	reqs := reqNames()
+	lay := defaultLayout()
+	m := &GroupMaster{Version: t.version}
+	for i, items := range t.rows {
+		rule := GroupRule{
+			Code:     items[pos[0]],
+			Name:     items[pos[1]],
+			Required: strings.Fields(items[pos[2]]),
+			KojinId:  items[pos[3]],
+			Defaults: map[string]string{},
+			Line:     t.lines[i],
+		}
+
+		if rule.Code == "" {
+			return nil, fmt.Errorf("%s %d行目: 所属cd1は必須です", name, rule.Line)
+		}
+		for _, before := range m.Rules {
+			if before.Code == rule.Code {
+				return nil, fmt.Errorf("%s %d行目: 所属cd1[%s]は%d行目と重複しています", name, rule.Line, rule.Code, before.Line)
+			}
+		}
+
+		for _, req := range rule.Required {
+			if !contains(reqs, req) {
+				return nil, fmt.Errorf("%s %d行目: 必須項目[%s]はチェックできません(%s)", name, rule.Line, req, strings.Join(reqs, " "))
+			}
+		}
+
+		if !validKojinId(rule.KojinId) {
+			return nil, fmt.Errorf("%s %d行目: 個人ID[%s]は「なし」「必須」「先頭X」のどれかにしてください", name, rule.Line, rule.KojinId)
+		}
+
+		for _, def := range strings.Fields(items[pos[4]]) {
+			kv := strings.SplitN(def, "=", 2)
+			if len(kv) != 2 || kv[0] == "" {
+				return nil, fmt.Errorf("%s %d行目: 既定値[%s]は「項目名=値」にしてください", name, rule.Line, def)
+			}
+			if _, ok := lay[kv[0]]; !ok {
+				return nil, fmt.Errorf("%s %d行目: 既定値の項目[%s]は抽出データにありません", name, rule.Line, kv[0])
+			}
+			rule.Defaults[kv[0]] = kv[1]
+		}
+
+		m.Rules = append(m.Rules, rule)
+	}
+
+	return m, nil
+}
+
+func (m *GroupMaster) Find(cd string) (*GroupRule, error) {
+	// 所属cd1の所属ルールを返す（無ければ「*」の行を返す）
+
+	var other *GroupRule
+	for i := range m.Rules {
+		switch m.Rules[i].Code {
+		case cd:
+			return &m.Rules[i], nil
+		case "*":
+			other = &m.Rules[i]
+		}
+	}
+
+	if other == nil {
+		return nil, fmt.Errorf("所属cd1[%s]の所属ルールがありません。所属ルールマスタを確認してください", cd)
+	}
+
+	return other, nil
+}
+
+func (rule *GroupRule) required(name string) bool {
+	// 必須チェックする項目ならtrueを返す
+
+	return rule != nil && contains(rule.Required, name)
+}
+
+func validKojinId(method string) bool {
+	// 個人IDの確認方法が正しければtrueを返す
+
+	switch {
+	case method == "なし", method == "必須":
+		return true
+	case strings.HasPrefix(method, "先頭") && method != "先頭":
+		return true
+	default:
+		return false
+	}
+}
+
+func reqNames() []string {
+	// 必須チェックできる項目名を列定義の順に返す
+
+	var names []string
+	for _, col := range columns {
+		if col.req != "" && !contains(names, col.req) {
+			names = append(names, col.req)
+		}
+	}
+
+	return names
+}
+
+func contains(list []string, str string) bool {
+	// list に str があればtrueを返す
+
+	for _, s := range list {
+		if s == str {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,119 @@
+package ricohsanai
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGroupFind(t *testing.T) {
+	// 所属cd1の行が無ければ「*」の行を使い、「*」の行も無ければエラー
+
+	m := DefaultGroups()
+	if rule, err := m.Find("04019001"); err != nil || rule.Name != "（株）リコー" {
+		t.Errorf("04019001: %+v %v", rule, err)
+	}
+	if rule, err := m.Find("98009001"); err != nil || rule.Code != "*" {
+		t.Errorf("98009001: %+v %v", rule, err)
+	}
+
+	m = &GroupMaster{Rules: []GroupRule{{Code: "04019001"}}}
+	if _, err := m.Find("98009001"); err == nil {
+		t.Error("所属ルールが無くてもエラーになりません")
+	}
+}
+
+func TestKojinIdChk(t *testing.T) {
+	// 個人IDは所属ルールの確認方法で確認する
+
+	tests := []struct {
+		id, method string
+		ok         bool
+	}{
+		{"", "なし", true},
+		{"12345", "なし", true},
+		{"", "必須", false},
+		{"12345", "必須", true},
+		{"K0002", "先頭K", true},
+		{"12345", "先頭K", false},
+		{"", "先頭K", false},
+	}
+	for _, tt := range tests {
+		if _, err := kojinIdChk(tt.id, tt.method); (err == nil) != tt.ok {
+			t.Errorf("kojinIdChk(%q, %q) err %v", tt.id, tt.method, err)
+		}
+	}
+}
+
+func TestLoadGroups(t *testing.T) {
+	// 所属ルールマスタの誤りは行番号つきのエラーにする
+
+	const head = "版,test\n所属cd1,所属名,必須項目,個人ID,既定値\n"
+	m, err := LoadGroups(strings.NewReader(head + "12345678,新会社,所属名1 所属名2,必須,所属名2=本社 所属cd2=0001\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule := m.Rules[0]
+	if !rule.required("所属名2") || rule.required("所属cd2") || rule.Defaults["所属名2"] != "本社" || rule.Defaults["所属cd2"] != "0001" {
+		t.Errorf("所属ルール %+v", rule)
+	}
+
+	bad := []string{
+		",新会社,,なし,",
+		"12345678,新会社,年齢,なし,",
+		"12345678,新会社,,先頭,",
+		"12345678,新会社,,なし,所属名2",
+		"12345678,新会社,,なし,部署=本社",
+		"04019001,リコー,,なし,\n04019001,リコー,,なし,",
+	}
+	for _, line := range bad {
+		if _, err := LoadGroups(strings.NewReader(head + line + "\n")); err == nil || !strings.Contains(err.Error(), "行目") {
+			t.Errorf("[%s] err %v", line, err)
+		}
+	}
+}
+
+func TestGroupRules(t *testing.T) {
+	// 必須項目・個人ID・既定値は所属cd1の所属ルールで決める
+
+	groups, err := LoadGroups(strings.NewReader(`版,test
+所属cd1,所属名,必須項目,個人ID,既定値
+04019001,（株）リコー,漢字氏名,なし,
+12345678,新会社,所属cd2 所属名2,必須,所属名2=本社
+*,上記以外,所属cd2,先頭K,
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header, rows := readTestRows(t)
+	row := setTestValue(header, setTestValue(header, rows[0], "所属cd2", ""), "所属名2", "")
+	tests := []struct {
+		cd      string
+		kojinId string
+		req, id int
+		name2   string
+	}{
+		{"04019001", "12345", 0, 0, ""},
+		{"12345678", "12345", 1, 0, "本社"},
+		{"12345678", "", 1, 1, "本社"},
+		{"98009001", "12345", 1, 1, ""},
+		{"98009001", "K0001", 1, 0, ""},
+	}
+	for _, tt := range tests {
+		c := testConverter()
+		c.Groups = groups
+		if _, err := c.SetHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		got, issues := c.ConvertRecord(setTestValue(header, setTestValue(header, row, "所属cd1", tt.cd), "社員No", tt.kojinId))
+		if n := countIssues(issues, CodeRequired); n != tt.req {
+			t.Errorf("%s: REQ %d件, want %d件 %v", tt.cd, n, tt.req, issues)
+		}
+		if n := countIssues(issues, CodeKojinId); n != tt.id {
+			t.Errorf("%s 個人ID[%s]: ID %d件, want %d件", tt.cd, tt.kojinId, n, tt.id)
+		}
+		if name2 := got[colIndex("事業所名称")]; name2 != tt.name2 {
+			t.Errorf("%s: 事業所名称 %q, want %q", tt.cd, name2, tt.name2)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package ricohsanai
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Severity は問題の重要度
+type Severity int
+
+const (
+	Warning Severity = iota // 確認が必要（提出はできる）
+	Error                   // 修正が必要
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Warning:
+		return "警告"
+	case Error:
+		return "エラー"
+	default:
+		return fmt.Sprintf("重要度%d", int(s))
+	}
+}
+
+// エラーコード
+const (
+	CodeConvert   = "CONV"    // 値を変換できない
+	CodeRequired  = "REQ"     // 必須項目が空欄
+	CodeKojinId   = "ID"      // 個人IDの確認
+	CodeAge       = "AGE"     // 年齢を読めない
+	CodeCourse    = "COURSE"  // コースを決められない
+	CodeRange     = "RANGE"   // 基準値が決まらない
+	CodeGroup     = "GROUP"   // 所属ルールが無い
+	CodeDuplicate = "DUP"     // 同じ受診のデータが複数ある
+	CodeSimilar   = "SIMILAR" // 同じ人らしいデータの個人IDが違う
+	CodeSent      = "SENT"    // 提出済み
+	CodeChanged   = "CHANGED" // 提出後に内容が変わった
+	CodeCorrect   = "CORRECT" // 訂正する受診番号が無い
+	CodePending   = "PENDING" // 結果待ちの検査がある
+	CodeChar      = "CHAR"    // 書き出せない文字・置き換えた文字がある
+	CodeLength    = "LEN"     // 最大バイト数を超えたので切り捨てた
+	CodeDate      = "DATE"    // 日付を読めない・ありえない日付
+	CodeLayout    = "LAYOUT"  // タイトル行の項目の位置が項目マスタと違う
+)
+
+// Issue は変換時に見つかった問題を表す
+type Issue struct {
+	Severity Severity
+	Code     string // エラーコード
+	JusinNo  string // 受診番号
+	Name     string // 氏名
+	JDay     string // 受診日
+	Course   string // NWのコース名
+	Src      string // 抽出データの項目名
+	Column   string // 出力CSVの項目名
+	Value    string // 問題のある値
+	Message  string
+}
+
+func (is Issue) String() string {
+	// ログ用に「[重要度:コード] 受診番号 氏名 出力項目: メッセージ」の形式で返す
+
+	str := fmt.Sprintf("[%s:%s] %s %s", is.Severity, is.Code, is.JusinNo, is.Name)
+	if is.Column != "" {
+		str += " " + is.Column
+	}
+
+	return str + ": " + is.Message
+}
+
+// issueError はエラーコードと問題の値を持つエラー
+// 列の変換関数がこれを返すと Issue のコード・項目名・値に使われる
+type issueError struct {
+	sev   Severity
+	code  string
+	src   string
+	value string
+	err   error
+}
+
+func (e *issueError) Error() string {
+	return e.err.Error()
+}
+
+func issue(code string, err error) error {
+	// エラーにエラーコードをつける
+
+	if err == nil {
+		return nil
+	}
+
+	return &issueError{sev: Error, code: code, err: err}
+}
+
+func warn(code string, err error) error {
+	// エラーを警告にする
+
+	if err == nil {
+		return nil
+	}
+
+	return &issueError{sev: Warning, code: code, err: err}
+}
+
+func issueOf(code string, src string, value string, err error) error {
+	// エラーにエラーコード・抽出データの項目名・値をつける
+
+	if err == nil {
+		return nil
+	}
+
+	return &issueError{sev: Error, code: code, src: src, value: value, err: err}
+}
+
+// Summary は問題の件数をまとめたもの
+type Summary struct {
+	Records  int            // 書き出したレコード件数
+	Rejects  int            // 除外した行数
+	Skipped  int            // 提出済みのため書き出さなかった件数
+	Errors   int            // エラーの件数
+	Warnings int            // 警告の件数
+	ByCode   map[string]int // エラーコードごとの件数
+}
+
+func Summarize(res *Result) Summary {
+	// 処理結果の問題を集計する
+
+	sum := Summary{Records: res.Count, Rejects: len(res.Rejects), Skipped: res.Skipped, ByCode: map[string]int{}}
+	for _, is := range res.Issues {
+		switch is.Severity {
+		case Error:
+			sum.Errors++
+		case Warning:
+			sum.Warnings++
+		}
+		sum.ByCode[is.Code]++
+	}
+
+	return sum
+}
+
+func (sum Summary) Blocking() bool {
+	// 修正が必要な問題があればtrueを返す
+
+	return sum.Errors > 0 || sum.Rejects > 0
+}
+
+func (sum Summary) Lines() []string {
+	// ログ用に集計結果を返す
+
+	lines := []string{
+		fmt.Sprintf("レコード件数:%d 除外:%d エラー:%d 警告:%d", sum.Records, sum.Rejects, sum.Errors, sum.Warnings),
+	}
+
+	if sum.Skipped > 0 {
+		lines = append(lines, fmt.Sprintf("提出済みのため書き出さなかった件数:%d", sum.Skipped))
+	}
+
+	codes := make([]string, 0, len(sum.ByCode))
+	for code := range sum.ByCode {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		lines = append(lines, fmt.Sprintf("　%s:%d件", code, sum.ByCode[code]))
+	}
+
+	return lines
+}
+
+// issueTitle は問題一覧のタイトル行
+var issueTitle = []string{"受診番号", "氏名", "受診日", "コース", "項目", "値", "問題", "修正方法", "重要度", "コード", "出力項目"}
+
+func issueRows(issues []Issue) [][]string {
+	// 問題一覧の行を作成する（受付で担当者ごとに分けられるように受診者の情報を先に並べる）
+
+	rows := make([][]string, 0, len(issues)+1)
+	rows = append(rows, issueTitle)
+	for _, is := range issues {
+		rows = append(rows, []string{is.JusinNo, is.Name, is.JDay, is.Course, is.Src, is.Value, is.Message, is.Fix(), is.Severity.String(), is.Code, is.Column})
+	}
+
+	return rows
+}
+
+func (is Issue) Fix() string {
+	// 修正方法を返す
+
+	switch is.Code {
+	case CodeRequired:
+		return "NWで" + is.Src + "を入力してください"
+	case CodeKojinId:
+		return "NWの社員Noを確認してください（所属ルールマスタの個人IDの確認方法も確認）"
+	case CodeLayout:
+		return "抽出パターンを確認し、項目名が変わった時は項目マスタの別名に登録してください"
+	case CodeDate:
+		return "NWの" + is.Src + "を確認してください（和暦は元号の期間、西暦は年4桁）"
+	case CodeAge:
+		return "NWの生年月日と年齢を確認してください"
+	case CodeCourse:
+		return "NWのコースを確認するか、コースマスタにコースを登録してください"
+	case CodeRange:
+		return "基準値マスタに" + is.Src + "の基準値を追加してください"
+	case CodeDuplicate:
+		return "抽出し直したデータを前のデータに追加していないか確認してください"
+	case CodeSimilar:
+		return "NWで同じ人が別の個人IDで登録されていないか確認してください"
+	case CodeSent:
+		return "前回までに提出したデータです。新しいデータだけ提出する時は -new-only を付けて変換してください"
+	case CodeChanged:
+		return "提出後にNWで修正されたデータです。訂正データとして提出するか確認してください"
+	case CodeCorrect:
+		return "訂正する受診番号の一覧と抽出データを確認してください"
+	case CodePending:
+		return "結果がそろったら変換し直して、correct で訂正データとして再送してください"
+	case CodeChar:
+		return "NWの" + is.Src + "の文字を確認するか、置換文字マスタに置換文字を登録してください"
+	case CodeLength:
+		return "切り捨てた文字列は健保に届きません。医師に確認し、NWの所見・コメントを短くしてください"
+	case CodeGroup:
+		return "NWの所属を確認するか、所属ルールマスタに所属cd1を追加してください"
+	case CodeConvert:
+		if is.Src != "" {
+			return "NWの" + is.Src + "の値を確認してください"
+		}
+		return "NWの入力値を確認してください"
+	default:
+		return ""
+	}
+}
+
+func WriteIssues(w io.Writer, issues []Issue) error {
+	// 問題の一覧をshift-JISのCSVで書き出す（Excelで並べ替え・絞り込みできるように）
+
+	writer := csv.NewWriter(cp932Writer(w))
+	writer.UseCRLF = true
+
+	if err := writer.WriteAll(issueRows(issues)); err != nil {
+		return err
+	}
+
+	return writer.Error()
+}
+
+func WriteIssuesXlsx(w io.Writer, issues []Issue) error {
+	// 問題の一覧をExcelのファイル(xlsx)で書き出す
+
+	return writeXlsx(w, "問題一覧", issueRows(issues))
+}
@@ -0,0 +1,171 @@
+package ricohsanai
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+//go:embed master/layout.csv
+var defaultLayoutCSV []byte
+
+// LayoutField は項目マスタの１行
+type LayoutField struct {
+	Pos     int      // 抽出データの列番号(0始まり)
+	Name    string   // 項目名(変換ではこの名前で値を使う)
+	Aliases []string // タイトル行での別名
+	Line    int      // 項目マスタの行番号
+}
+
+// LayoutMaster は「A96 三愛グループ健診データ提出用」パターンで抽出される項目のうち
+// 変換で使う項目の一覧。抽出パターンを変更した時は項目マスタを合わせること
+type LayoutMaster struct {
+	Version string
+	Fields  []LayoutField
+}
+
+// a96Fields は内蔵の項目マスタの項目（変換で使う項目名はこれに含まれること）
+var a96Fields = DefaultLayout().Fields
+
+func DefaultLayout() *LayoutMaster {
+	// 内蔵の項目マスタを返す
+
+	m, err := loadLayout(bytes.NewReader(defaultLayoutCSV), nil)
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+func DefaultLayoutCSV() []byte {
+	// 内蔵の項目マスタのファイル内容を返す
+
+	return defaultLayoutCSV
+}
+
+func LoadLayout(r io.Reader) (*LayoutMaster, error) {
+	// 項目マスタを読み込む（変換で使う項目が全てあること）
+
+	return loadLayout(r, a96Fields)
+}
+
+func loadLayout(r io.Reader, required []LayoutField) (*LayoutMaster, error) {
+	// 項目マスタを読み込み、required の項目名が全てあるか確認する
+
+	const name = "項目マスタ"
+	t, err := readTable(r, name)
+	if err != nil {
+		return nil, err
+	}
+
+	pos, err := t.columns(name, "列", "項目名", "別名")
+	if err != nil {
+		return nil, err
+	}
+
+	m := &LayoutMaster{Version: t.version}
+	seen := map[string]bool{}
+	for i, items := range t.rows {
+		f := LayoutField{Name: items[pos[1]], Aliases: strings.Fields(items[pos[2]]), Line: t.lines[i]}
+		n, err := strconv.Atoi(items[pos[0]])
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("%s %d行目: 列[%s]は1以上の数字で入力してください", name, f.Line, items[pos[0]])
+		}
+		f.Pos = n - 1
+		if f.Name == "" {
+			return nil, fmt.Errorf("%s %d行目: 項目名は必須です", name, f.Line)
+		}
+		if seen[f.Name] {
+			return nil, fmt.Errorf("%s %d行目: 項目名[%s]が重複しています", name, f.Line, f.Name)
+		}
+		seen[f.Name] = true
+
+		m.Fields = append(m.Fields, f)
+	}
+
+	var miss []string
+	for _, f := range required {
+		if !seen[f.Name] {
+			miss = append(miss, f.Name)
+		}
+	}
+	if len(miss) > 0 {
+		return nil, fmt.Errorf("%s: 変換で使う項目[%s]がありません", name, strings.Join(miss, " "))
+	}
+
+	return m, nil
+}
+
+// layout は抽出データの項目名から列番号を引く
+type layout map[string]int
+
+func defaultLayout() layout {
+	// 内蔵の項目マスタの列番号のままの layout を返す
+
+	lay := layout{}
+	for _, f := range a96Fields {
+		lay[f.Name] = f.Pos
+	}
+
+	return lay
+}
+
+func newLayout(m *LayoutMaster, header []string) (layout, []string, error) {
+	// タイトル行から各項目の列番号を求める
+	// 項目名・別名が見つかればその列を使う（位置が変わった時は警告を返す）
+	// 項目名・別名のどちらも無い項目があればエラーを返す（列の位置で読み替えはしない）
+
+	pos := map[string]int{}
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if _, ok := pos[name]; !ok {
+			pos[name] = i
+		}
+	}
+
+	var msg, miss []string
+	lay := layout{}
+	for _, f := range m.Fields {
+		i, ok := pos[f.Name]
+		for _, alias := range f.Aliases {
+			if ok {
+				break
+			}
+			i, ok = pos[alias]
+		}
+		switch {
+		case !ok && f.Pos < len(header):
+			miss = append(miss, fmt.Sprintf("項目[%s]がありません(%d列目は[%s])", f.Name, f.Pos+1, header[f.Pos]))
+			continue
+		case !ok:
+			miss = append(miss, fmt.Sprintf("項目[%s]がありません(%d列目までしかありません)", f.Name, len(header)))
+			continue
+		case i != f.Pos:
+			msg = append(msg, fmt.Sprintf("項目[%s]が%d列目にあります(%d列目のはず)。%d列目を使います", f.Name, i+1, f.Pos+1, i+1))
+		}
+		lay[f.Name] = i
+	}
+
+	if len(miss) > 0 {
+		return nil, msg, fmt.Errorf("抽出データのタイトル行が「A96 三愛グループ健診データ提出用」と違います。項目名が変わった時は項目マスタの別名に登録してください\r\n%s", strings.Join(miss, "\r\n"))
+	}
+
+	return lay, msg, nil
+}
+
+func (lay layout) missing(names []string) []string {
+	// layout に無い項目名を返す
+
+	var miss []string
+	for _, name := range names {
+		if _, ok := lay[name]; !ok {
+			miss = append(miss, name)
+		}
+	}
+
+	return miss
+}
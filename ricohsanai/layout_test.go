@@ -0,0 +1,90 @@
+package ricohsanai
+
+import (
+	"strings"
+	"testing"
+)
+
+func testHeader() []string {
+	// 内蔵の項目マスタどおりのタイトル行
+
+	m := DefaultLayout()
+	n := 0
+	for _, f := range m.Fields {
+		if f.Pos >= n {
+			n = f.Pos + 1
+		}
+	}
+	header := make([]string, n)
+	for i := range header {
+		header[i] = "未使用"
+	}
+	for _, f := range m.Fields {
+		header[f.Pos] = f.Name
+	}
+
+	return header
+}
+
+func TestNewLayout(t *testing.T) {
+	// 項目名・別名で列を決め、見つからない項目はエラーにする
+
+	m := DefaultLayout()
+	header := testHeader()
+	bmi := m.Fields[0]
+	for _, f := range m.Fields {
+		if f.Name == "BMI" {
+			bmi = f
+		}
+	}
+	if bmi.Name != "BMI" {
+		t.Fatal("項目マスタにBMIがありません")
+	}
+
+	// マスタどおり
+	lay, warns, err := newLayout(m, header)
+	if err != nil || len(warns) != 0 {
+		t.Fatalf("warns %v err %v", warns, err)
+	}
+	if lay["BMI"] != bmi.Pos {
+		t.Errorf("BMI %d列目, want %d列目", lay["BMI"]+1, bmi.Pos+1)
+	}
+
+	// 項目名が変わった時は別名が無ければエラー（元の列の値は使わない）
+	renamed := append([]string(nil), header...)
+	renamed[bmi.Pos] = "BMI値"
+	if _, _, err := newLayout(m, renamed); err == nil || !strings.Contains(err.Error(), "項目[BMI]") {
+		t.Errorf("項目名が変わってもエラーになりません: %v", err)
+	}
+
+	// 別名に登録すればその列を使う
+	alias := &LayoutMaster{Version: m.Version, Fields: append([]LayoutField(nil), m.Fields...)}
+	for i := range alias.Fields {
+		if alias.Fields[i].Name == "BMI" {
+			alias.Fields[i].Aliases = []string{"BMI値"}
+		}
+	}
+	lay, warns, err = newLayout(alias, renamed)
+	if err != nil || len(warns) != 0 {
+		t.Fatalf("別名 warns %v err %v", warns, err)
+	}
+	if lay["BMI"] != bmi.Pos {
+		t.Errorf("別名 BMI %d列目, want %d列目", lay["BMI"]+1, bmi.Pos+1)
+	}
+
+	// 位置が変わった時は見つかった列を使って警告
+	moved := append(append([]string(nil), header...), "BMI")
+	moved[bmi.Pos] = "未使用"
+	lay, warns, err = newLayout(m, moved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lay["BMI"] != len(moved)-1 || len(warns) != 1 {
+		t.Errorf("位置が変わった時 BMI %d列目 警告 %v", lay["BMI"]+1, warns)
+	}
+
+	// タイトル行が短い時もエラー
+	if _, _, err := newLayout(m, header[:bmi.Pos]); err == nil {
+		t.Error("タイトル行が短くてもエラーになりません")
+	}
+}
@@ -0,0 +1,178 @@
+package ricohsanai
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// LedgerEntry は提出台帳の１行（提出したレコード１件）
+type LedgerEntry struct {
+	JusinNo string // 受診番号
+	KojinId string // 個人ID
+	JDay    string // 受診日
+	Course  string // コースコード
+	Hash    string // 書き出した行のハッシュ(作成日・提出日・登録完了区分は除く)
+	File    string // 提出ファイル名
+	Time    string // 提出日時
+}
+
+// Ledger は提出済みのレコードを記録する提出台帳
+type Ledger struct {
+	Version string
+	Entries []LedgerEntry
+}
+
+// ledgerTitle は提出台帳のタイトル行
+var ledgerTitle = []string{"受診番号", "個人ID", "受診日", "コース", "ハッシュ", "提出ファイル", "提出日時"}
+
+// ledgerSkip はハッシュに含めない列
+// 作成日・提出日は変換するたびに変わり、登録完了区分は訂正データでは訂正の値になる
+var ledgerSkip = []int{colIndex("データ作成日"), colIndex("データ提出日"), colIndex("データ登録完了区分")}
+
+func LoadLedger(r io.Reader) (*Ledger, error) {
+	// 提出台帳を読み込む
+
+	const name = "提出台帳"
+	t, err := readTable(r, name)
+	if err != nil {
+		return nil, err
+	}
+
+	pos, err := t.columns(name, ledgerTitle...)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Ledger{Version: t.version}
+	for _, items := range t.rows {
+		l.Entries = append(l.Entries, LedgerEntry{
+			JusinNo: items[pos[0]],
+			KojinId: items[pos[1]],
+			JDay:    items[pos[2]],
+			Course:  items[pos[3]],
+			Hash:    items[pos[4]],
+			File:    items[pos[5]],
+			Time:    items[pos[6]],
+		})
+	}
+
+	return l, nil
+}
+
+func (l *Ledger) Write(w io.Writer) error {
+	// 提出台帳を書き出す（メモ帳・Excelで開けるようにBOM付きUTF-8）
+
+	if _, err := io.WriteString(w, "\xef\xbb\xbf"); err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	writer.UseCRLF = true
+
+	version := l.Version
+	if version == "" {
+		version = "1"
+	}
+	rows := [][]string{{"版", version}, ledgerTitle}
+	for _, e := range l.Entries {
+		rows = append(rows, []string{e.JusinNo, e.KojinId, e.JDay, e.Course, e.Hash, e.File, e.Time})
+	}
+
+	return writer.WriteAll(rows)
+}
+
+func (l *Ledger) Add(entries []LedgerEntry, file string, time string) int {
+	// 提出したレコードを変換ごと(提出ファイル名・提出日時)に追加する
+	// 同じ提出ファイル名の前の変換の記録も残し、その件数を返す（同じ日に変換し直した時）
+
+	n := 0
+	for _, e := range l.Entries {
+		if e.File == file {
+			n++
+		}
+	}
+
+	for _, e := range entries {
+		e.File, e.Time = file, time
+		l.Entries = append(l.Entries, e)
+	}
+
+	return n
+}
+
+func (l *Ledger) Find(e LedgerEntry) (LedgerEntry, bool) {
+	// 提出済みのレコードを探す
+	// 受診番号で探し、無ければ個人ID・受診日で探す。何度も提出していれば最後の記録を返す
+
+	match := []func(sent LedgerEntry) bool{
+		func(sent LedgerEntry) bool { return e.JusinNo != "" && sent.JusinNo == e.JusinNo },
+		func(sent LedgerEntry) bool {
+			return e.KojinId != "" && sent.KojinId == e.KojinId && sent.JDay == e.JDay
+		},
+	}
+
+	for _, m := range match {
+		for i := len(l.Entries) - 1; i >= 0; i-- {
+			if sent := l.Entries[i]; m(sent) {
+				return sent, true
+			}
+		}
+	}
+
+	return LedgerEntry{}, false
+}
+
+func ledgerEntry(rec *record, row []string) LedgerEntry {
+	// 書き出す行から提出台帳の記録を作る
+
+	cols := submissionCols
+	return LedgerEntry{
+		JusinNo: rec.jusinNo,
+		KojinId: row[cols.kojinId],
+		JDay:    row[cols.jday],
+		Course:  row[cols.courseCd],
+		Hash:    rowHash(row),
+	}
+}
+
+func rowHash(row []string) string {
+	// 書き出す行のハッシュを返す（作成日・提出日・登録完了区分は除く）
+
+	h := sha256.New()
+	for i, v := range row {
+		for _, skip := range ledgerSkip {
+			if i == skip {
+				v = ""
+			}
+		}
+		fmt.Fprintf(h, "%d:%s\n", i, strings.ReplaceAll(v, "\n", " "))
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (rec *record) sentIssue(sent LedgerEntry, changed bool) Issue {
+	// 提出済みのレコードを問題として返す
+
+	is := Issue{
+		Severity: Warning,
+		Code:     CodeSent,
+		JusinNo:  rec.jusinNo,
+		Name:     rec.name,
+		JDay:     rec.get("受診日"),
+		Course:   rec.get("コース名"),
+		Src:      "受診番号",
+		Value:    rec.jusinNo,
+		Message:  fmt.Sprintf("提出済みです(%s %s)", sent.File, sent.Time),
+	}
+	if changed {
+		is.Code = CodeChanged
+		is.Message = fmt.Sprintf("提出後に内容が変わっています(%s %s)。訂正データの候補です", sent.File, sent.Time)
+	}
+
+	return is
+}
@@ -0,0 +1,69 @@
+package ricohsanai
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestLedgerAddFind(t *testing.T) {
+	// 変換ごとに記録し、同じ提出ファイル名で変換し直しても前の記録を残す
+
+	l := &Ledger{}
+	a := LedgerEntry{JusinNo: "100000", KojinId: "12345", JDay: "2024/06/11", Course: "21", Hash: "a"}
+	b := LedgerEntry{JusinNo: "100001", KojinId: "K0002", JDay: "2024/07/14", Course: "60", Hash: "b"}
+
+	if n := l.Add([]LedgerEntry{a}, "d.csv", "2024/07/01 09:00:00"); n != 0 {
+		t.Errorf("前の記録 %d件, want 0件", n)
+	}
+	// -new-only で変換し直した時は新しいレコードだけ
+	if n := l.Add([]LedgerEntry{b}, "d.csv", "2024/07/01 10:00:00"); n != 1 {
+		t.Errorf("前の記録 %d件, want 1件", n)
+	}
+	if len(l.Entries) != 2 {
+		t.Fatalf("記録 %d件, want 2件", len(l.Entries))
+	}
+
+	sent, found := l.Find(LedgerEntry{JusinNo: "100000"})
+	if !found || sent.Time != "2024/07/01 09:00:00" || sent.File != "d.csv" {
+		t.Errorf("受診番号で見つかりません %+v", sent)
+	}
+
+	// 受診番号が無ければ個人ID・受診日で探す
+	if sent, found := l.Find(LedgerEntry{KojinId: "K0002", JDay: "2024/07/14"}); !found || sent.Hash != "b" {
+		t.Errorf("個人ID・受診日で見つかりません %+v", sent)
+	}
+	if _, found := l.Find(LedgerEntry{KojinId: "K0002", JDay: "2024/07/15"}); found {
+		t.Error("受診日が違うのに見つかりました")
+	}
+	if _, found := l.Find(LedgerEntry{}); found {
+		t.Error("空の記録が見つかりました")
+	}
+
+	// 何度も提出していれば最後の記録
+	a2 := a
+	a2.Hash = "a2"
+	l.Add([]LedgerEntry{a2}, "e.csv", "2024/07/10 09:00:00")
+	if sent, _ := l.Find(LedgerEntry{JusinNo: "100000"}); sent.Hash != "a2" || sent.File != "e.csv" {
+		t.Errorf("最後の記録ではありません %+v", sent)
+	}
+}
+
+func TestLedgerWriteLoad(t *testing.T) {
+	// 書き出した提出台帳を読み込むと同じ記録になる
+
+	l := &Ledger{}
+	l.Add([]LedgerEntry{{JusinNo: "100000", KojinId: "12345", JDay: "2024/06/11", Course: "21", Hash: "a"}}, "d.csv", "2024/07/01 09:00:00")
+
+	var buf bytes.Buffer
+	if err := l.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadLedger(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Entries, l.Entries) {
+		t.Errorf("読み込んだ記録 %+v, want %+v", got.Entries, l.Entries)
+	}
+}
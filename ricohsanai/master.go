@@ -0,0 +1,125 @@
+package ricohsanai
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/transform"
+)
+
+// table はマスタファイル(CSV)の内容
+// 1行目は「版,xxx」、2行目はタイトル行、#で始まる行はコメント
+type table struct {
+	version string
+	header  []string
+	rows    [][]string
+	lines   []int // 各行のファイル上の行番号
+}
+
+func readTable(r io.Reader, name string) (*table, error) {
+	// マスタファイルを読み込む
+	// Excelで保存したshift-JISでも、メモ帳で保存したUTF-8でも読めるようにする
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = decodeText(data)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	t := &table{}
+	for {
+		items, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%s: %s", name, err)
+		}
+
+		for i := range items {
+			items[i] = strings.TrimSpace(items[i])
+		}
+
+		line, _ := reader.FieldPos(0)
+		switch {
+		case t.version == "" && t.header == nil:
+			if len(items) < 2 || items[0] != "版" {
+				return nil, fmt.Errorf("%s: 1行目に「版,」がありません", name)
+			}
+			t.version = items[1]
+		case t.header == nil:
+			t.header = items
+		default:
+			for len(items) < len(t.header) {
+				items = append(items, "")
+			}
+			t.rows = append(t.rows, items)
+			t.lines = append(t.lines, line)
+		}
+	}
+
+	if t.header == nil {
+		return nil, fmt.Errorf("%s: タイトル行がありません", name)
+	}
+
+	return t, nil
+}
+
+func decodeText(data []byte) ([]byte, error) {
+	// BOMを取り、shift-JISならUTF-8にする
+
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")) // BOM
+	if !utf8.Valid(data) {
+		var err error
+		data, _, err = transform.Bytes(cp932.NewDecoder(), data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
+
+func (t *table) columns(name string, want ...string) ([]int, error) {
+	// タイトル行から want の各項目の位置を返す
+
+	pos := make([]int, len(want))
+	for i, w := range want {
+		pos[i] = -1
+		for j, h := range t.header {
+			if h == w {
+				pos[i] = j
+				break
+			}
+		}
+		if pos[i] < 0 {
+			return nil, fmt.Errorf("%s: 項目[%s]がありません", name, w)
+		}
+	}
+
+	return pos, nil
+}
+
+func (t *table) optional(want string) int {
+	// タイトル行から無くてもよい項目の位置を返す（無ければ-1）
+
+	for j, h := range t.header {
+		if h == want {
+			return j
+		}
+	}
+
+	return -1
+}
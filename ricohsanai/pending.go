@@ -0,0 +1,188 @@
+package ricohsanai
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+//go:embed master/pending.csv
+var defaultPendingCSV []byte
+
+// データ登録完了区分
+const kubunDone = "1" // 登録完了
+
+// PendingRule は結果待ちマスタの１行
+type PendingRule struct {
+	Name     string   // 検査名
+	Done     []string // 実施項目（どれかに値があれば実施した）
+	DoneText string   // 実施の文字（指定があれば実施項目にこの文字を含む時だけ実施した）
+	Result   []string // 結果項目（すべて空欄なら結果待ち）
+	Line     int      // 結果待ちマスタの行番号
+}
+
+// PendingMaster は結果待ちの検査を見つける結果待ちマスタ
+type PendingMaster struct {
+	Version string
+	Rules   []PendingRule
+}
+
+func DefaultPending() *PendingMaster {
+	// 内蔵の結果待ちマスタを返す
+
+	m, err := LoadPending(bytes.NewReader(defaultPendingCSV))
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+func DefaultPendingCSV() []byte {
+	// 内蔵の結果待ちマスタのファイル内容を返す
+
+	return defaultPendingCSV
+}
+
+func LoadPending(r io.Reader) (*PendingMaster, error) {
+	// 結果待ちマスタを読み込む
+
+	const name = "結果待ちマスタ"
+	t, err := readTable(r, name)
+	if err != nil {
+		return nil, err
+	}
+
+	pos, err := t.columns(name, "検査名", "実施項目", "結果項目")
+	if err != nil {
+		return nil, err
+	}
+
+	text := t.optional("実施の文字")
+
+	lay := defaultLayout()
+	m := &PendingMaster{Version: t.version}
+	for i, items := range t.rows {
+		rule := PendingRule{
+			Name:   items[pos[0]],
+			Done:   strings.Fields(items[pos[1]]),
+			Result: strings.Fields(items[pos[2]]),
+			Line:   t.lines[i],
+		}
+		if text >= 0 {
+			rule.DoneText = items[text]
+		}
+
+		if rule.Name == "" || len(rule.Done) == 0 || len(rule.Result) == 0 {
+			return nil, fmt.Errorf("%s %d行目: 検査名・実施項目・結果項目は必須です", name, rule.Line)
+		}
+		if missing := lay.missing(append(append([]string(nil), rule.Done...), rule.Result...)); len(missing) > 0 {
+			return nil, fmt.Errorf("%s %d行目: 項目[%s]は抽出データにありません", name, rule.Line, strings.Join(missing, " "))
+		}
+
+		m.Rules = append(m.Rules, rule)
+	}
+
+	return m, nil
+}
+
+func ReadPendingList(r io.Reader) (map[string][]string, error) {
+	// 結果待ちの一覧(受診番号,検査名,検査名…)を読み込む
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data, err = decodeText(data)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	list := map[string][]string{}
+	for {
+		items, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		no := strings.TrimSpace(items[0])
+		if no == "" {
+			continue
+		}
+		for _, exam := range items[1:] {
+			if exam = strings.TrimSpace(exam); exam != "" && !contains(list[no], exam) {
+				list[no] = append(list[no], exam)
+			}
+		}
+		if len(list[no]) == 0 {
+			list[no] = append(list[no], "検査")
+		}
+	}
+
+	return list, nil
+}
+
+func (rec *record) pendingExams() []string {
+	// 結果待ちの検査名を返す（結果待ちマスタと結果待ちの一覧から）
+
+	if rec.pending != nil {
+		return *rec.pending
+	}
+
+	var exams []string
+	if rec.c.Pending != nil {
+		for _, rule := range rec.c.Pending.Rules {
+			if rec.done(rule) && !rec.anyValue(rule.Result) {
+				exams = append(exams, rule.Name)
+			}
+		}
+	}
+	for _, exam := range rec.c.PendingList[rec.jusinNo] {
+		if !contains(exams, exam) {
+			exams = append(exams, exam)
+		}
+	}
+
+	rec.pending = &exams
+	return exams
+}
+
+func (rec *record) done(rule PendingRule) bool {
+	// 結果待ちマスタの検査を実施したか（実施項目のどれかに値、実施の文字の指定があればその文字がある）
+
+	for _, name := range rule.Done {
+		if v := rec.get(name); v != "" && strings.Contains(v, rule.DoneText) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (rec *record) anyValue(names []string) bool {
+	// 項目のどれかに値があればtrueを返す
+
+	for _, name := range names {
+		if rec.get(name) != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func pendingMessage(exams []string) string {
+	// 登録未完了の連絡内容を作る
+
+	return strings.Join(exams, "・") + "の結果待ちのため、結果がそろい次第再送します"
+}
@@ -0,0 +1,32 @@
+package ricohsanai
+
+import "testing"
+
+func TestPendingExams(t *testing.T) {
+	// 胃内視鏡生検は内視鏡の所見に「生検」があり、生検判定が空欄なら結果待ち（生検所見は条件にしない）
+
+	c := testConverter()
+	tests := []struct {
+		values map[string]string
+		want   bool
+	}{
+		{map[string]string{}, false},
+		{map[string]string{"胃内視鏡所見1": "慢性胃炎"}, false},
+		{map[string]string{"胃内視鏡所見1": "胃ポリープ(生検)"}, true},
+		{map[string]string{"胃内視鏡コメント": "生検施行"}, true},
+		{map[string]string{"胃内視鏡所見2": "生検", "胃生検所見1": "Group1"}, true},
+		{map[string]string{"胃内視鏡所見2": "生検", "胃内視鏡生検判定": "Ａ"}, false},
+		{map[string]string{"胃生検所見1": "Group1"}, false},
+	}
+	for _, tt := range tests {
+		items := make([]string, c.fields)
+		for name, v := range tt.values {
+			items[c.lay[name]] = v
+		}
+		rec := c.newRecord(items)
+		got := contains(rec.pendingExams(), "胃内視鏡生検")
+		if got != tt.want {
+			t.Errorf("%v: 結果待ち %v, want %v", tt.values, got, tt.want)
+		}
+	}
+}
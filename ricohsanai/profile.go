@@ -0,0 +1,156 @@
+package ricohsanai
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"strings"
+)
+
+//go:embed master/profile.csv
+var defaultProfileCSV []byte
+
+// profileItems は施設プロファイルの項目（この順にログへ書き出す）
+var profileItems = []string{
+	"提出先",
+	"データ作成者",
+	"団体コード",
+	"健診機関名称",
+	"特定健診機関番号",
+	"健診実施医師名",
+	"訂正データの登録完了区分",
+	"未完了データの登録完了区分",
+}
+
+// profileDefaults は省略できる項目の既定値（前の版の施設プロファイルにない項目）
+var profileDefaults = map[string]string{
+	"訂正データの登録完了区分":  "",
+	"未完了データの登録完了区分": "2",
+}
+
+// profileBlank は空欄でもよい項目（使う時に確認する）
+var profileBlank = []string{"訂正データの登録完了区分"}
+
+// Profile は健診データに書き出す施設・提出先の固定値
+type Profile struct {
+	Version string
+	values  map[string]string
+}
+
+func DefaultProfile() *Profile {
+	// 内蔵の施設プロファイルを返す
+
+	p, err := LoadProfile(bytes.NewReader(defaultProfileCSV))
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+func DefaultProfileCSV() []byte {
+	// 内蔵の施設プロファイルのファイル内容を返す
+
+	return defaultProfileCSV
+}
+
+func LoadProfile(r io.Reader) (*Profile, error) {
+	// 施設プロファイルを読み込む
+
+	const name = "施設プロファイル"
+	t, err := readTable(r, name)
+	if err != nil {
+		return nil, err
+	}
+
+	pos, err := t.columns(name, "項目", "値")
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Profile{Version: t.version, values: map[string]string{}}
+	for i, items := range t.rows {
+		key, val := items[pos[0]], items[pos[1]]
+		if !contains(profileItems, key) {
+			return nil, fmt.Errorf("%s %d行目: 項目[%s]は使えません(%s)", name, t.lines[i], key, strings.Join(profileItems, "、"))
+		}
+		if _, ok := p.values[key]; ok {
+			return nil, fmt.Errorf("%s %d行目: 項目[%s]が重複しています", name, t.lines[i], key)
+		}
+		p.values[key] = val
+	}
+
+	for key, val := range profileDefaults {
+		if _, ok := p.values[key]; !ok {
+			p.values[key] = val
+		}
+	}
+
+	if err := p.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %s", name, err)
+	}
+
+	return p, nil
+}
+
+func (p *Profile) validate() error {
+	// 施設プロファイルの値を確認する
+
+	var msgs []string
+	for _, key := range profileItems {
+		val, ok := p.values[key]
+		switch {
+		case !ok:
+			msgs = append(msgs, fmt.Sprintf("項目[%s]がありません", key))
+		case val == "" && !contains(profileBlank, key):
+			msgs = append(msgs, fmt.Sprintf("項目[%s]が空欄です", key))
+		default:
+			if _, err := cp932.NewEncoder().String(val); err != nil {
+				msgs = append(msgs, fmt.Sprintf("項目[%s]にshift-JISで書き出せない文字があります[%s]", key, val))
+			}
+		}
+	}
+
+	if no := p.values["特定健診機関番号"]; no != "" && !isDigits(no, 10) {
+		msgs = append(msgs, fmt.Sprintf("特定健診機関番号[%s]は10桁の数字にしてください", no))
+	}
+
+	if len(msgs) > 0 {
+		return fmt.Errorf("%s", strings.Join(msgs, "\r\n"))
+	}
+
+	return nil
+}
+
+func (p *Profile) Get(key string) string {
+	// 施設プロファイルの値を返す
+
+	return p.values[key]
+}
+
+func (p *Profile) Items() [][2]string {
+	// 施設プロファイルの項目と値を決まった順に返す
+
+	items := make([][2]string, len(profileItems))
+	for i, key := range profileItems {
+		items[i] = [2]string{key, p.values[key]}
+	}
+
+	return items
+}
+
+func isDigits(str string, n int) bool {
+	// n桁の数字ならtrueを返す
+
+	if len(str) != n {
+		return false
+	}
+	for _, c := range str {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
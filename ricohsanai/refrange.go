@@ -0,0 +1,155 @@
+package ricohsanai
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+//go:embed master/range.csv
+var defaultRangeCSV []byte
+
+// RefRange は基準値マスタの１行
+type RefRange struct {
+	Test   string  // 検査
+	Lower  float64 // 下限値
+	Upper  float64 // 上限値
+	HasLow bool    // 下限値あり
+	HasUp  bool    // 上限値あり
+	Sex    string  // 性別(空欄は男女とも)
+	AgeMin int     // 年齢下限(0は下限なし)
+	AgeMax int     // 年齢上限(0は上限なし)
+	From   string  // 適用開始(受診日)
+	To     string  // 適用終了(受診日)
+	Line   int     // 基準値マスタの行番号
+}
+
+// RangeMaster は腫瘍マーカーの陰・陽区分を決める基準値マスタ
+type RangeMaster struct {
+	Version string
+	Ranges  []RefRange
+}
+
+func DefaultRanges() *RangeMaster {
+	// 内蔵の基準値マスタを返す
+
+	m, err := LoadRanges(bytes.NewReader(defaultRangeCSV))
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+func DefaultRangesCSV() []byte {
+	// 内蔵の基準値マスタのファイル内容を返す
+
+	return defaultRangeCSV
+}
+
+func LoadRanges(r io.Reader) (*RangeMaster, error) {
+	// 基準値マスタを読み込む
+
+	const name = "基準値マスタ"
+	t, err := readTable(r, name)
+	if err != nil {
+		return nil, err
+	}
+
+	pos, err := t.columns(name, "検査", "下限値", "上限値", "性別", "年齢下限", "年齢上限", "適用開始", "適用終了")
+	if err != nil {
+		return nil, err
+	}
+
+	m := &RangeMaster{Version: t.version}
+	for i, items := range t.rows {
+		rr := RefRange{
+			Test: items[pos[0]],
+			Sex:  items[pos[3]],
+			From: strings.Replace(items[pos[6]], "-", "/", -1),
+			To:   strings.Replace(items[pos[7]], "-", "/", -1),
+			Line: t.lines[i],
+		}
+
+		if rr.Test == "" {
+			return nil, fmt.Errorf("%s %d行目: 検査は必須です", name, rr.Line)
+		}
+		if rr.Sex != "" && rr.Sex != "男" && rr.Sex != "女" {
+			return nil, fmt.Errorf("%s %d行目: 性別[%s]は「男」「女」または空欄にしてください", name, rr.Line, rr.Sex)
+		}
+
+		if items[pos[1]] != "" {
+			if rr.Lower, err = strconv.ParseFloat(items[pos[1]], 64); err != nil {
+				return nil, fmt.Errorf("%s %d行目: 下限値[%s]が数値ではありません", name, rr.Line, items[pos[1]])
+			}
+			rr.HasLow = true
+		}
+		if items[pos[2]] != "" {
+			if rr.Upper, err = strconv.ParseFloat(items[pos[2]], 64); err != nil {
+				return nil, fmt.Errorf("%s %d行目: 上限値[%s]が数値ではありません", name, rr.Line, items[pos[2]])
+			}
+			rr.HasUp = true
+		}
+		if items[pos[4]] != "" {
+			if rr.AgeMin, err = strconv.Atoi(items[pos[4]]); err != nil {
+				return nil, fmt.Errorf("%s %d行目: 年齢下限[%s]が数値ではありません", name, rr.Line, items[pos[4]])
+			}
+		}
+		if items[pos[5]] != "" {
+			if rr.AgeMax, err = strconv.Atoi(items[pos[5]]); err != nil {
+				return nil, fmt.Errorf("%s %d行目: 年齢上限[%s]が数値ではありません", name, rr.Line, items[pos[5]])
+			}
+		}
+
+		m.Ranges = append(m.Ranges, rr)
+	}
+
+	return m, nil
+}
+
+func (m *RangeMaster) Find(test string, jday string, sex string, age int) (RefRange, bool) {
+	// 受診日・性別・年齢に合う基準値を返す
+
+	jday = normDay(jday)
+	for _, rr := range m.Ranges {
+		switch {
+		case rr.Test != test:
+		case rr.From != "" && jday < rr.From:
+		case rr.To != "" && jday > rr.To:
+		case rr.Sex != "" && rr.Sex != sex:
+		case rr.AgeMin != 0 && age < rr.AgeMin:
+		case rr.AgeMax != 0 && age > rr.AgeMax:
+		default:
+			return rr, true
+		}
+	}
+
+	return RefRange{}, false
+}
+
+func (m *RangeMaster) Classify(test string, value string, jday string, sex string, age int) (string, error) {
+	// 陰・陽区分(1:-(陰性) 3:+(陽性))を返す
+
+	if value == "" {
+		return "", nil
+	}
+
+	num, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return value, fmt.Errorf("%s数値変換エラー[%s]", test, value)
+	}
+
+	rr, ok := m.Find(test, jday, sex, age)
+	if !ok {
+		return "", issue(CodeRange, fmt.Errorf("%sの基準値がありません(受診日[%s] 性別[%s] 年齢[%d])。基準値マスタを確認してください", test, jday, sex, age))
+	}
+
+	if (rr.HasUp && num > rr.Upper) || (rr.HasLow && num < rr.Lower) {
+		return "3", nil
+	}
+
+	return "1", nil
+}
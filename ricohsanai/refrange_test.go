@@ -0,0 +1,111 @@
+package ricohsanai
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRangeClassify(t *testing.T) {
+	// 内蔵の基準値マスタは上限値を超えた時に陽性
+
+	m := DefaultRanges()
+	tests := []struct {
+		test, value, want string
+	}{
+		{"PSA", "4.00", "1"},
+		{"PSA", "4.01", "3"},
+		{"CA125", "35.0", "1"},
+		{"CA19-9", "37.1", "3"},
+		{"CEA", "5.0", "1"},
+		{"AFP", "10.1", "3"},
+		{"シフラ", "3.5", "1"},
+		{"シフラ", "", ""},
+	}
+	for _, tt := range tests {
+		got, err := m.Classify(tt.test, tt.value, "2024/06/11", "男", 44)
+		if err != nil || got != tt.want {
+			t.Errorf("Classify(%s, %s) = %q %v, want %q", tt.test, tt.value, got, err, tt.want)
+		}
+	}
+
+	if _, err := m.Classify("PSA", "abc", "2024/06/11", "男", 44); err == nil {
+		t.Error("数値でなくてもエラーになりません")
+	}
+}
+
+func TestRangeEffective(t *testing.T) {
+	// 基準値が変わった前後の受診日、性別・年齢で基準値を選ぶ
+
+	m, err := LoadRanges(strings.NewReader(`版,test
+検査,下限値,上限値,性別,年齢下限,年齢上限,適用開始,適用終了
+CEA,,5.0,,,,,2024/03/31
+CEA,,6.0,,,,2024-04-01,
+PSA,,3.0,男,,49,,
+PSA,,4.0,男,50,,,
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		test, value, jday, sex string
+		age                    int
+		want                   string
+	}{
+		{"CEA", "5.5", "2024/03/31", "男", 44, "3"},
+		{"CEA", "5.5", "2024-04-01", "男", 44, "1"},
+		{"CEA", "5.5", "R06.04.01", "女", 44, "1"},
+		{"PSA", "3.5", "2024/06/11", "男", 49, "3"},
+		{"PSA", "3.5", "2024/06/11", "男", 50, "1"},
+	}
+	for _, tt := range tests {
+		got, err := m.Classify(tt.test, tt.value, tt.jday, tt.sex, tt.age)
+		if err != nil || got != tt.want {
+			t.Errorf("Classify(%s, %s, %s, %s, %d) = %q %v, want %q", tt.test, tt.value, tt.jday, tt.sex, tt.age, got, err, tt.want)
+		}
+	}
+
+	// 合う基準値が無い時は RANGE のエラー
+	_, err = m.Classify("PSA", "3.5", "2024/06/11", "女", 50)
+	var ie *issueError
+	if !errors.As(err, &ie) || ie.code != CodeRange {
+		t.Errorf("基準値が無い時 %v", err)
+	}
+
+	for _, line := range []string{",,5.0,,,,,", "CEA,,abc,,,,,", "CEA,,5.0,M,,,,", "CEA,,5.0,,20代,,,"} {
+		csv := "版,test\n検査,下限値,上限値,性別,年齢下限,年齢上限,適用開始,適用終了\n" + line + "\n"
+		if _, err := LoadRanges(strings.NewReader(csv)); err == nil || !strings.Contains(err.Error(), "3行目") {
+			t.Errorf("[%s] err %v", line, err)
+		}
+	}
+}
+
+func TestRangeSameRun(t *testing.T) {
+	// 基準値が変わる前と後の受診日のデータを同じ変換で正しく判定する
+
+	m, err := LoadRanges(strings.NewReader("版,test\n検査,下限値,上限値,性別,年齢下限,年齢上限,適用開始,適用終了\nCEA,,5.0,,,,,2024/03/31\nCEA,,6.0,,,,2024/04/01,\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header, rows := readTestRows(t)
+	before := setTestValue(header, setTestValue(header, rows[0], "CEA", "5.5"), "受診日", "2024-03-29")
+	after := setTestValue(header, rows[1], "CEA", "5.5")
+
+	c := testConverter()
+	c.Ranges = m
+	var out bytes.Buffer
+	if _, err := c.ConvertFiles([]Input{testInput(t, "a.txt", header, before, after)}, &out); err != nil {
+		t.Fatal(err)
+	}
+	col := colIndex("　CEA　陰・陽区分")
+	written := readTestCSV(t, out.Bytes())
+	if len(written) != 2 {
+		t.Fatalf("書き出したレコード %d件, want 2件", len(written))
+	}
+	if written[0][col] != "3" || written[1][col] != "1" {
+		t.Errorf("陰・陽区分 %q %q, want \"3\" \"1\"", written[0][col], written[1][col])
+	}
+}
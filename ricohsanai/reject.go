@@ -0,0 +1,28 @@
+package ricohsanai
+
+import (
+	"io"
+	"strings"
+)
+
+func WriteRejects(w io.Writer, header []string, rejects []Reject) error {
+	// 除外した行を抽出データと同じ形式(タブ区切り)で書き出す
+	// 修正後にそのまま変換し直せるようにタイトル行も書き出す
+
+	// 抽出データと同じく引用符を付けずに書き出す
+	tw := cp932Writer(w)
+	if _, err := io.WriteString(tw, strings.Join(header, "\t")+"\r\n"); err != nil {
+		return err
+	}
+
+	for _, rj := range rejects {
+		if rj.Items == nil { // 読めなかった行は書き出せない
+			continue
+		}
+		if _, err := io.WriteString(tw, strings.Join(rj.Items, "\t")+"\r\n"); err != nil {
+			return err
+		}
+	}
+
+	return tw.Close()
+}
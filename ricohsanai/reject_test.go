@@ -0,0 +1,63 @@
+package ricohsanai
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRejects(t *testing.T) {
+	// 列数が合わない行は除外して残りの行を変換し、除外した行は修正用に書き出せる
+
+	header, rows := readTestRows(t)
+	short := rows[1][:25]
+	long := append(append([]string(nil), rows[2]...), "余分")
+
+	c := testConverter()
+	var out bytes.Buffer
+	res, err := c.ConvertFiles([]Input{testInput(t, "a.txt", header, rows[0], short, long)}, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Count != 1 || len(readTestCSV(t, out.Bytes())) != 1 {
+		t.Errorf("書き出したレコード %d件, want 1件", res.Count)
+	}
+	if fc := res.Files[0]; fc.Rows != 3 || fc.Count != 1 || fc.Rejects != 2 {
+		t.Errorf("ファイルごとの件数 %+v", fc)
+	}
+
+	tests := []struct {
+		line    int
+		jusinNo string
+		reason  string
+		items   []string
+	}{
+		{3, "100001", "列数が足りません", short},
+		{4, "100002", "列数が多すぎます", long},
+	}
+	if len(res.Rejects) != len(tests) {
+		t.Fatalf("除外した行 %v", res.Rejects)
+	}
+	for i, tt := range tests {
+		rj := res.Rejects[i]
+		if rj.File != "a.txt" || rj.Line != tt.line || rj.JusinNo != tt.jusinNo || !strings.Contains(rj.Reason, tt.reason) {
+			t.Errorf("除外した行 %s, want %d行目 %s %s", rj, tt.line, tt.jusinNo, tt.reason)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := WriteRejects(&buf, header, res.Rejects); err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(cp932Reader(&buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(b), "\r\n"), "\r\n")
+	want := []string{strings.Join(header, "\t"), strings.Join(short, "\t"), strings.Join(long, "\t")}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("除外した行のファイル %d行, want %d行", len(lines), len(want))
+	}
+}
@@ -0,0 +1,205 @@
+package ricohsanai
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Tally は事業所・コースごとの件数
+type Tally struct {
+	Code  string
+	Name  string
+	Count int
+}
+
+// Submission は提出データの集計（CDラベルに記載する内容）
+// 書き出した行から集計するので提出ファイルと件数がずれない
+type Submission struct {
+	Facility  string  // 医療機関名
+	FirstDay  string  // 受診日(最初)
+	LastDay   string  // 受診日(最後)
+	SubmitDay string  // 提出日
+	Count     int     // レコード件数
+	Pending   int     // 未完了(結果待ち)のレコード件数
+	Offices   []Tally // 事業所ごとの件数
+	Courses   []Tally // コースごとの件数
+	Files     []Tally // 抽出データのファイルごとの件数
+}
+
+// submissionCols は集計に使う出力CSVの列
+var submissionCols = struct {
+	jday, submit, officeCd, officeName, courseCd, courseName, kojinId int
+}{
+	jday:       colIndex("受診日"),
+	submit:     colIndex("データ提出日"),
+	officeCd:   colIndex("事業所コード"),
+	officeName: colIndex("事業所名称"),
+	courseCd:   colIndex("コースコード"),
+	courseName: colIndex("コース名称"),
+	kojinId:    colIndex("個人ID"),
+}
+
+func colIndex(title string) int {
+	// 出力CSVの列番号(0始まり)を返す
+
+	for i, col := range columns {
+		if col.title == title {
+			return i
+		}
+	}
+
+	panic("列定義に[" + title + "]がありません")
+}
+
+func (s *Submission) add(row []string, pending bool) {
+	// 書き出した１行分を集計する（pending は結果待ちで未完了のレコード）
+
+	cols := submissionCols
+	s.Count++
+	if pending {
+		s.Pending++
+	}
+
+	if jday := row[cols.jday]; jday != "" {
+		if s.FirstDay == "" || jday < s.FirstDay {
+			s.FirstDay = jday
+		}
+		if jday > s.LastDay {
+			s.LastDay = jday
+		}
+	}
+	if s.SubmitDay == "" {
+		s.SubmitDay = row[cols.submit]
+	}
+
+	s.Offices = tally(s.Offices, row[cols.officeCd], row[cols.officeName])
+	s.Courses = tally(s.Courses, row[cols.courseCd], row[cols.courseName])
+}
+
+func tally(list []Tally, code string, name string) []Tally {
+	// コードと名称ごとに件数を数える（コード順に並べる）
+
+	if code == "" && name == "" {
+		name = "(空欄)"
+	}
+
+	for i := range list {
+		if list[i].Code == code && list[i].Name == name {
+			list[i].Count++
+			return list
+		}
+	}
+
+	list = append(list, Tally{Code: code, Name: name, Count: 1})
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Code != list[j].Code {
+			return list[i].Code < list[j].Code
+		}
+		return list[i].Name < list[j].Name
+	})
+
+	return list
+}
+
+func (s *Submission) Lines() []string {
+	// 集計結果をテキストで返す
+
+	lines := []string{
+		"リコー三愛グループ健康保険組合　健診データ提出",
+		"",
+		"医療機関名　：" + s.Facility,
+		"受診日　　　：" + s.FirstDay + " ～ " + s.LastDay,
+		"提出日　　　：" + s.SubmitDay,
+		fmt.Sprintf("レコード件数：%d件", s.Count),
+	}
+	if s.Pending > 0 {
+		lines = append(lines, fmt.Sprintf("　うち未完了：%d件", s.Pending))
+	}
+	lines = append(lines,
+		"",
+		"【事業所別】",
+	)
+	for _, t := range s.Offices {
+		lines = append(lines, fmt.Sprintf("　%s %s：%d件", t.Code, t.Name, t.Count))
+	}
+
+	lines = append(lines, "", "【コース別】")
+	for _, t := range s.Courses {
+		lines = append(lines, fmt.Sprintf("　%s %s：%d件", t.Code, t.Name, t.Count))
+	}
+
+	if len(s.Files) > 1 {
+		lines = append(lines, "", "【抽出ファイル別】")
+		for _, t := range s.Files {
+			lines = append(lines, fmt.Sprintf("　%s：%d件", t.Name, t.Count))
+		}
+	}
+
+	return lines
+}
+
+func WriteSubmission(w io.Writer, s *Submission) error {
+	// 集計結果をshift-JISのテキストで書き出す
+
+	tw := cp932Writer(w)
+	if _, err := io.WriteString(tw, strings.Join(s.Lines(), "\r\n")+"\r\n"); err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
+
+var submissionHTML = template.Must(template.New("submission").Parse(`<!DOCTYPE html>
+<html lang="ja">
+<head>
+<meta charset="UTF-8">
+<title>健診データ提出 {{.SubmitDay}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+.label { border: 1px solid #000; padding: 1em 1.5em; width: 28em; }
+.label h1 { font-size: 1.1em; margin: 0 0 .8em; }
+.label th { text-align: left; font-weight: normal; padding-right: 1em; }
+table.count { border-collapse: collapse; margin-top: 1em; }
+table.count th, table.count td { border: 1px solid #999; padding: .2em .6em; }
+table.count td.num { text-align: right; }
+@media print { body { margin: 0; } h2 { page-break-before: always; } }
+</style>
+</head>
+<body>
+<div class="label">
+<h1>リコー三愛グループ健康保険組合　健診データ提出</h1>
+<table>
+<tr><th>医療機関名</th><td>{{.Facility}}</td></tr>
+<tr><th>受診日</th><td>{{.FirstDay}} ～ {{.LastDay}}</td></tr>
+<tr><th>提出日</th><td>{{.SubmitDay}}</td></tr>
+<tr><th>レコード件数</th><td>{{.Count}}件</td></tr>
+{{if .Pending}}<tr><th>うち未完了</th><td>{{.Pending}}件</td></tr>
+{{end}}</table>
+</div>
+<h2>事業所別</h2>
+<table class="count">
+<tr><th>事業所コード</th><th>事業所名称</th><th>件数</th></tr>
+{{range .Offices}}<tr><td>{{.Code}}</td><td>{{.Name}}</td><td class="num">{{.Count}}</td></tr>
+{{end}}</table>
+<h2>コース別</h2>
+<table class="count">
+<tr><th>コースコード</th><th>コース名称</th><th>件数</th></tr>
+{{range .Courses}}<tr><td>{{.Code}}</td><td>{{.Name}}</td><td class="num">{{.Count}}</td></tr>
+{{end}}</table>
+{{if gt (len .Files) 1}}<h2>抽出ファイル別</h2>
+<table class="count">
+<tr><th>ファイル</th><th>件数</th></tr>
+{{range .Files}}<tr><td>{{.Name}}</td><td class="num">{{.Count}}</td></tr>
+{{end}}</table>
+{{end}}</body>
+</html>
+`))
+
+func WriteSubmissionHTML(w io.Writer, s *Submission) error {
+	// 集計結果を印刷用のHTMLで書き出す
+
+	return submissionHTML.Execute(w, s)
+}
@@ -0,0 +1,137 @@
+package ricohsanai
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// xlsxParts はxlsxファイルのシート以外の部品
+var xlsxParts = []struct {
+	name string
+	body string
+}{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+</Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+</Relationships>`},
+}
+
+func writeXlsx(w io.Writer, sheet string, rows [][]string) error {
+	// 1シートだけのxlsxファイルを書き出す
+	// 1行目はタイトル行として固定し、オートフィルタを付ける
+
+	cols := 0
+	for _, row := range rows {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+	ref := "A1"
+	if cols > 0 && len(rows) > 0 {
+		ref = "A1:" + cellName(cols-1, len(rows)-1)
+	}
+
+	zw := zip.NewWriter(w)
+	for _, p := range xlsxParts {
+		f, err := zw.Create(p.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, p.body); err != nil {
+			return err
+		}
+	}
+
+	// ブック
+	f, err := zw.Create("xl/workbook.xml")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(f, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>
+<definedNames><definedName name="_xlnm._FilterDatabase" localSheetId="0" hidden="1">'%s'!%s</definedName></definedNames>
+</workbook>`, xmlText(sheet), xmlText(sheet), absRef(ref))
+
+	// シート
+	f, err = zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>
+<sheetData>`)
+	for r, row := range rows {
+		fmt.Fprintf(&buf, `<row r="%d">`, r+1)
+		for c, v := range row {
+			if v == "" {
+				continue
+			}
+			fmt.Fprintf(&buf, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, cellName(c, r), xmlText(v))
+		}
+		buf.WriteString(`</row>`)
+	}
+	fmt.Fprintf(&buf, `</sheetData>
+<autoFilter ref="%s"/>
+</worksheet>`, ref)
+	if _, err := buf.WriteTo(f); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+func cellName(col int, row int) string {
+	// 列番号・行番号(0始まり)からセル名(A1など)を返す
+
+	name := ""
+	for col++; col > 0; col = (col - 1) / 26 {
+		name = string(rune('A'+(col-1)%26)) + name
+	}
+
+	return name + strconv.Itoa(row+1)
+}
+
+func absRef(ref string) string {
+	// A1:K10 を $A$1:$K$10 にする
+
+	str := ""
+	prev := ':'
+	for _, c := range ref {
+		switch {
+		case c >= 'A' && c <= 'Z' && (prev == ':' || str == ""):
+			str += "$"
+		case c >= '0' && c <= '9' && prev >= 'A' && prev <= 'Z':
+			str += "$"
+		}
+		str += string(c)
+		prev = c
+	}
+
+	return str
+}
+
+func xmlText(str string) string {
+	// XMLの文字をエスケープする
+
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(str))
+	return buf.String()
+}